@@ -26,6 +26,10 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// rbacSelfCheck is run by BeforeServe. It is a variable so that tests can
+// replace it.
+var rbacSelfCheck = retriever.RBACSelfCheck
+
 // New returns a new CSI Storage Plug-in Provider.
 func New() retriever.PluginProvider {
 	svc := service.New()
@@ -38,12 +42,12 @@ func New() retriever.PluginProvider {
 		// modify the SP's interceptors, server options, or prevent the
 		// server from starting by returning a non-nil error.
 		BeforeServe: func(
-			_ context.Context,
+			ctx context.Context,
 			_ *retriever.Plugin,
 			_ net.Listener,
 		) error {
 			log.WithField("service", "MetadataRetriever").Debug("BeforeServe")
-			return nil
+			return rbacSelfCheck(ctx)
 		},
 
 		EnvVars: []string{
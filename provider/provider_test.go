@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"net"
 	"testing"
 
@@ -72,3 +73,16 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_RBACSelfCheck(t *testing.T) {
+	defer func() {
+		rbacSelfCheck = retriever.RBACSelfCheck
+	}()
+	rbacSelfCheck = func(_ context.Context) error {
+		return errors.New("missing RBAC permissions")
+	}
+
+	plugin := New().(*retriever.Plugin)
+	err := plugin.BeforeServe(context.Background(), plugin, &mockListener{})
+	assert.EqualError(t, err, "missing RBAC permissions")
+}
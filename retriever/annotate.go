@@ -0,0 +1,162 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// annotationFieldManager is the field manager of the annotations applied
+// by AnnotateVolume.
+const annotationFieldManager = "csi-metadata-retriever"
+
+// AnnotateVolumeRequest defines API request type. Either Name and
+// NameSpace of a PVC or VolumeName of a PV must be set.
+type AnnotateVolumeRequest struct {
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace  string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	VolumeName string `protobuf:"bytes,3,opt,name=volume_name,proto3" json:"volume_name,omitempty"`
+	// Annotations must all be under X_CSI_RETRIEVER_ANNOTATION_PREFIX.
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// AnnotateVolumeResponse defines API response type
+type AnnotateVolumeResponse struct {
+	// Annotations holds the annotations of the object under the reserved
+	// prefix after the update.
+	Annotations map[string]string `protobuf:"bytes,1,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// AnnotateVolume sets annotations under the reserved prefix on a PVC or a
+// PV with server-side apply. The retriever owns the annotations it
+// applies, so those it applied before and that are missing from the
+// request are removed.
+func (s *MetadataRetrieverClientType) AnnotateVolume(
+	ctx context.Context,
+	req *AnnotateVolumeRequest) (
+	*AnnotateVolumeResponse, error,
+) {
+	log.Infof("Annotate volume PVC %s/%s PV %s with %d annotations",
+		req.NameSpace, req.Name, req.VolumeName, len(req.Annotations))
+	if req.Name == "" && req.VolumeName == "" {
+		return nil, errors.New("PVC Name or Volume Name must be set")
+	}
+	if req.Name != "" && req.VolumeName != "" {
+		return nil, status.Error(codes.InvalidArgument, "only one of PVC Name and Volume Name can be set")
+	}
+
+	prefix := annotationPrefix()
+	if prefix == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s is not set", EnvVarAnnotationPrefix)
+	}
+	if err := validateAnnotations(prefix, req.Annotations); err != nil {
+		log.Error("Invalid annotations: ", err)
+		return nil, err
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	// Look the object up first, as applying to a missing object would
+	// try to create it.
+	opts := metav1.ApplyOptions{FieldManager: annotationFieldManager, Force: true}
+	var annotations map[string]string
+	if req.VolumeName != "" {
+		pv, err := clientset.CoreV1().PersistentVolumes().Get(ctx, req.VolumeName, metav1.GetOptions{})
+		if err != nil {
+			log.Error("Error retrieving PV info: ", err)
+			return nil, err
+		}
+		if err := s.checkPVAccess(pv); err != nil {
+			log.Error("Error checking PV access: ", err)
+			return nil, err
+		}
+		pv, err = clientset.CoreV1().PersistentVolumes().Apply(ctx,
+			corev1ac.PersistentVolume(req.VolumeName).WithAnnotations(req.Annotations), opts)
+		if err != nil {
+			log.Error("Error applying PV annotations: ", err)
+			return nil, err
+		}
+		annotations = pv.Annotations
+	} else {
+		pvc, err := clientset.CoreV1().PersistentVolumeClaims(req.NameSpace).Get(ctx, req.Name, metav1.GetOptions{})
+		if err != nil {
+			log.Error("Error retrieving PVC info: ", err)
+			return nil, err
+		}
+		if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+			log.Error("Error checking PVC access: ", err)
+			return nil, err
+		}
+		pvc, err = clientset.CoreV1().PersistentVolumeClaims(req.NameSpace).Apply(ctx,
+			corev1ac.PersistentVolumeClaim(req.Name, req.NameSpace).WithAnnotations(req.Annotations), opts)
+		if err != nil {
+			log.Error("Error applying PVC annotations: ", err)
+			return nil, err
+		}
+		annotations = pvc.Annotations
+	}
+
+	resp := &AnnotateVolumeResponse{Annotations: map[string]string{}}
+	for k, v := range annotations {
+		if strings.HasPrefix(k, prefix) {
+			resp.Annotations[k] = v
+		}
+	}
+	return resp, nil
+}
+
+// annotationPrefix returns the reserved annotation prefix, ending with a
+// slash, or "" if it is not configured.
+func annotationPrefix() string {
+	prefix := strings.TrimSpace(os.Getenv(EnvVarAnnotationPrefix))
+	if prefix == "" || strings.HasSuffix(prefix, "/") {
+		return prefix
+	}
+	return prefix + "/"
+}
+
+// validateAnnotations returns an InvalidArgument error listing the keys
+// that are not valid annotation keys under prefix.
+func validateAnnotations(prefix string, annotations map[string]string) error {
+	var invalid []string
+	for k := range annotations {
+		if !strings.HasPrefix(k, prefix) || len(validation.IsQualifiedName(k)) > 0 {
+			invalid = append(invalid, k)
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	sort.Strings(invalid)
+	return status.Errorf(codes.InvalidArgument,
+		"annotation keys must be valid names under %s: %s", prefix, strings.Join(invalid, ", "))
+}
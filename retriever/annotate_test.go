@@ -0,0 +1,113 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAnnotateVolume(t *testing.T) {
+	pvc := newTestPVC("pvc1", "ns1", nil)
+	pvc.Annotations = map[string]string{"owner": "team-a"}
+	fakeClientset := fake.NewClientset(pvc, newTestPV("pv1", "csi.dell.com", "handle-1", pvc))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	ctx := context.Background()
+	t.Setenv(EnvVarAnnotationPrefix, "array.dell.com")
+
+	resp, err := client.AnnotateVolume(ctx, &AnnotateVolumeRequest{
+		Name:      "pvc1",
+		NameSpace: "ns1",
+		Annotations: map[string]string{
+			"array.dell.com/serial": "APM001",
+			"array.dell.com/wwn":    "60000970000197900000",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"array.dell.com/serial": "APM001",
+		"array.dell.com/wwn":    "60000970000197900000",
+	}, resp.Annotations)
+
+	got, err := fakeClientset.CoreV1().PersistentVolumeClaims("ns1").Get(ctx, "pvc1", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", got.Annotations["owner"])
+	assert.Equal(t, "APM001", got.Annotations["array.dell.com/serial"])
+
+	resp, err = client.AnnotateVolume(ctx, &AnnotateVolumeRequest{
+		VolumeName:  "pv1",
+		Annotations: map[string]string{"array.dell.com/volume-name": "csivol-1234"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"array.dell.com/volume-name": "csivol-1234"}, resp.Annotations)
+}
+
+func TestAnnotateVolume_Errors(t *testing.T) {
+	fakeClientset := fake.NewClientset(newTestPVC("pvc1", "ns1", nil))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	ctx := context.Background()
+
+	_, err := client.AnnotateVolume(ctx, &AnnotateVolumeRequest{})
+	assert.EqualError(t, err, "PVC Name or Volume Name must be set")
+	_, err = client.AnnotateVolume(ctx, &AnnotateVolumeRequest{Name: "pvc1", VolumeName: "pv1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	t.Setenv(EnvVarAnnotationPrefix, "")
+	_, err = client.AnnotateVolume(ctx, &AnnotateVolumeRequest{Name: "pvc1", NameSpace: "ns1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	t.Setenv(EnvVarAnnotationPrefix, "array.dell.com/")
+	_, err = client.AnnotateVolume(ctx, &AnnotateVolumeRequest{
+		Name:      "pvc1",
+		NameSpace: "ns1",
+		Annotations: map[string]string{
+			"array.dell.com/serial": "APM001",
+			"owner":                 "team-b",
+			"array.dell.com/":       "",
+		},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "array.dell.com/, owner")
+
+	_, err = client.AnnotateVolume(ctx, &AnnotateVolumeRequest{Name: "missing", NameSpace: "ns1"})
+	assert.Contains(t, err.Error(), "not found")
+	_, err = client.AnnotateVolume(ctx, &AnnotateVolumeRequest{VolumeName: "missing"})
+	assert.Contains(t, err.Error(), "not found")
+
+	// PVs of other drivers are denied in restrict-to-driver mode.
+	t.Setenv(EnvVarDriverName, "csi.dell.com")
+	t.Setenv(EnvVarRestrictToDriver, "true")
+	fakeClientset = fake.NewClientset(newTestPV("pv1", "csi.example.com", "handle-1", nil))
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.AnnotateVolume(ctx, &AnnotateVolumeRequest{
+		VolumeName:  "pv1",
+		Annotations: map[string]string{"array.dell.com/serial": "APM001"},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.AnnotateVolume(ctx, &AnnotateVolumeRequest{VolumeName: "pv1"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+}
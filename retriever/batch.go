@@ -0,0 +1,237 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultBatchConcurrency bounds the number of concurrent lookups of a
+// batch request that does not set MaxConcurrency.
+const defaultBatchConcurrency = 8
+
+// maxBatchConcurrency caps the MaxConcurrency of a batch request.
+const maxBatchConcurrency = 32
+
+// PVCKey identifies a PVC either by name and namespace or by the driver
+// and volume handle of its bound CSI PV.
+type PVCKey struct {
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace    string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	VolumeHandle string `protobuf:"bytes,3,opt,name=volume_handle,proto3" json:"volume_handle,omitempty"`
+	// Driver of the volume handle. Defaults to the value of
+	// X_CSI_RETRIEVER_DRIVER_NAME.
+	Driver string `protobuf:"bytes,4,opt,name=driver,proto3" json:"driver,omitempty"`
+}
+
+// GetPVCLabelsBatchRequest defines API request type
+type GetPVCLabelsBatchRequest struct {
+	Keys []*PVCKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// MaxConcurrency bounds the number of concurrent lookups. Defaults to
+	// 8 and is capped at 32.
+	MaxConcurrency int32 `protobuf:"varint,2,opt,name=max_concurrency,proto3" json:"max_concurrency,omitempty"`
+	// Filter overrides the configured default key filter.
+	Filter *KeyFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// SanitizationProfile overrides the configured default profile.
+	SanitizationProfile string `protobuf:"bytes,4,opt,name=sanitization_profile,proto3" json:"sanitization_profile,omitempty"`
+}
+
+// PVCLabelsResult is the outcome of a single batch lookup. Exactly one of
+// Parameters or Error is set.
+type PVCLabelsResult struct {
+	Key        *PVCKey           `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Name       string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace  string            `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Parameters map[string]string `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Error      string            `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Sanitization reports the changes made by the sanitization profile.
+	Sanitization []*SanitizationChange `protobuf:"bytes,6,rep,name=sanitization,proto3" json:"sanitization,omitempty"`
+}
+
+// GetPVCLabelsBatchResponse defines API response type. Results are in the
+// same order as the request keys.
+type GetPVCLabelsBatchResponse struct {
+	Results []*PVCLabelsResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+// GetPVCLabelsBatch gets the labels of many PVCs in one call. Lookups run
+// with bounded concurrency and are served from the informer cache when it
+// has been started. A failed lookup is reported in its own result and does
+// not fail the batch.
+func (s *MetadataRetrieverClientType) GetPVCLabelsBatch(
+	ctx context.Context,
+	req *GetPVCLabelsBatchRequest) (
+	*GetPVCLabelsBatchResponse, error,
+) {
+	log.Infof("Get PVC labels for a batch of %d keys", len(req.Keys))
+
+	output, err := s.outputFor(req.Filter, req.SanitizationProfile)
+	if err != nil {
+		log.Error("Error reading output options: ", err)
+		return nil, err
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	pvsByHandle, err := s.batchPVsByHandle(ctx, clientset, req.Keys)
+	if err != nil {
+		log.Error("Error listing PVs: ", err)
+		return nil, err
+	}
+
+	concurrency := batchConcurrency(req.MaxConcurrency)
+
+	lookups := newPolicyLookups()
+	results := make([]*PVCLabelsResult, len(req.Keys))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, key := range req.Keys {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, key *PVCKey) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = s.getPVCLabelsForKey(ctx, clientset, pvsByHandle, lookups, output, key)
+		}(i, key)
+	}
+	wg.Wait()
+
+	return &GetPVCLabelsBatchResponse{Results: results}, nil
+}
+
+// batchConcurrency returns the number of concurrent lookups for a
+// requested MaxConcurrency.
+func batchConcurrency(requested int32) int {
+	switch {
+	case requested <= 0:
+		return defaultBatchConcurrency
+	case requested > maxBatchConcurrency:
+		return maxBatchConcurrency
+	}
+	return int(requested)
+}
+
+// batchPVsByHandle lists the PVs once for all volume-handle keys of a batch
+// when the informer cache is not available to answer them.
+func (s *MetadataRetrieverClientType) batchPVsByHandle(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	keys []*PVCKey,
+) (map[string]*v1.PersistentVolume, error) {
+	if s.getInformerCache() != nil {
+		return nil, nil
+	}
+	needed := false
+	for _, key := range keys {
+		if key != nil && key.Name == "" && key.VolumeHandle != "" {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return nil, nil
+	}
+
+	pvs, err := clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pvsByHandle := make(map[string]*v1.PersistentVolume, len(pvs.Items))
+	for i := range pvs.Items {
+		if csi := pvs.Items[i].Spec.CSI; csi != nil {
+			pvsByHandle[volumeHandleKey(csi.Driver, csi.VolumeHandle)] = &pvs.Items[i]
+		}
+	}
+	return pvsByHandle, nil
+}
+
+func (s *MetadataRetrieverClientType) getPVCLabelsForKey(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	pvsByHandle map[string]*v1.PersistentVolume,
+	lookups *policyLookups,
+	output *outputOptions,
+	key *PVCKey,
+) *PVCLabelsResult {
+	result := &PVCLabelsResult{Key: key}
+	fail := func(err error) *PVCLabelsResult {
+		log.Error("Error retrieving PVC info: ", err)
+		result.Error = err.Error()
+		return result
+	}
+
+	if key == nil || (key.Name == "" && key.VolumeHandle == "") {
+		return fail(errors.New("PVC Name or volume handle must be set"))
+	}
+
+	name, namespace := key.Name, key.NameSpace
+	if name == "" {
+		driver := key.Driver
+		if driver == "" {
+			driver = s.driverName
+		}
+		if driver == "" {
+			return fail(errors.New("driver name cannot be empty"))
+		}
+		var pv *v1.PersistentVolume
+		if pvsByHandle != nil {
+			pv = pvsByHandle[volumeHandleKey(driver, key.VolumeHandle)]
+			if pv == nil {
+				return fail(noPVError(driver, key.VolumeHandle))
+			}
+		} else {
+			var err error
+			if pv, err = s.lookupPVByVolumeHandle(ctx, clientset, driver, key.VolumeHandle); err != nil {
+				return fail(err)
+			}
+		}
+		if pv.Spec.ClaimRef == nil {
+			return fail(errors.New("PV " + pv.Name + " is not bound to a PVC"))
+		}
+		name, namespace = pv.Spec.ClaimRef.Name, pv.Spec.ClaimRef.Namespace
+	}
+
+	pvc, err := s.lookupPVC(ctx, clientset, namespace, name)
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Name = pvc.Name
+	result.NameSpace = pvc.Namespace
+	if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+		return fail(err)
+	}
+
+	output, _, err = s.outputForPVC(ctx, clientset, lookups, output, pvc)
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Parameters, result.Sanitization = output.apply("labels", pvc.Labels)
+	return result
+}
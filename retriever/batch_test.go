@@ -0,0 +1,138 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestPV(name, driver, handle string, claim *v1.PersistentVolumeClaim) *v1.PersistentVolume {
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: handle},
+			},
+		},
+	}
+	if claim != nil {
+		pv.Spec.ClaimRef = &v1.ObjectReference{
+			Kind:      "PersistentVolumeClaim",
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			UID:       claim.UID,
+		}
+	}
+	return pv
+}
+
+func TestGetPVCLabelsBatch(t *testing.T) {
+	pvc1 := newTestPVC("pvc1", "ns1", map[string]string{"app": "db"})
+	pvc2 := newTestPVC("pvc2", "ns2", map[string]string{"app": "web"})
+	theirs := newTestPVC("theirs", "ns2", map[string]string{"app": "other"})
+	fakeClientset := fake.NewSimpleClientset(
+		pvc1, pvc2, theirs,
+		newTestPV("pv-theirs", "csi.example.com", "handle-2", theirs),
+		newTestPV("pv2", "csi.dell.com", "handle-2", pvc2),
+		newTestPV("pv3", "csi.dell.com", "handle-3", nil),
+	)
+	t.Setenv(EnvVarDriverName, "csi.dell.com")
+
+	for _, cached := range []bool{false, true} {
+		client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+		ctx, cancel := context.WithCancel(context.Background())
+		if cached {
+			require.NoError(t, client.StartCache(ctx))
+		}
+
+		resp, err := client.GetPVCLabelsBatch(ctx, &GetPVCLabelsBatchRequest{
+			Keys: []*PVCKey{
+				{Name: "pvc1", NameSpace: "ns1"},
+				{VolumeHandle: "handle-2"},
+				{Name: "missing", NameSpace: "ns1"},
+				{VolumeHandle: "handle-3"},
+				{VolumeHandle: "handle-unknown"},
+				{},
+				nil,
+				{VolumeHandle: "handle-2", Driver: "csi.example.com"},
+			},
+			MaxConcurrency: 2,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 8)
+
+		assert.Equal(t, map[string]string{"app": "db"}, resp.Results[0].Parameters)
+		assert.Empty(t, resp.Results[0].Error)
+
+		assert.Equal(t, "pvc2", resp.Results[1].Name)
+		assert.Equal(t, "ns2", resp.Results[1].NameSpace)
+		assert.Equal(t, map[string]string{"app": "web"}, resp.Results[1].Parameters)
+
+		assert.Contains(t, resp.Results[2].Error, "not found")
+		assert.Equal(t, "PV pv3 is not bound to a PVC", resp.Results[3].Error)
+		assert.Equal(t, "no PV of driver csi.dell.com found for volume handle handle-unknown", resp.Results[4].Error)
+		assert.Equal(t, "PVC Name or volume handle must be set", resp.Results[5].Error)
+		assert.Equal(t, "PVC Name or volume handle must be set", resp.Results[6].Error)
+		assert.Equal(t, "theirs", resp.Results[7].Name)
+		assert.Equal(t, map[string]string{"app": "other"}, resp.Results[7].Parameters)
+		cancel()
+	}
+
+	// Volume handle keys need a driver.
+	t.Setenv(EnvVarDriverName, "")
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	resp, err := client.GetPVCLabelsBatch(context.Background(), &GetPVCLabelsBatchRequest{
+		Keys: []*PVCKey{{VolumeHandle: "handle-2"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "driver name cannot be empty", resp.Results[0].Error)
+}
+
+func TestGetPVCLabelsBatch_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientsetError)
+	_, err := client.GetPVCLabelsBatch(context.Background(), &GetPVCLabelsBatchRequest{})
+	assert.EqualError(t, err, "simulated clientset creation error")
+
+	fakeClientset := fake.NewSimpleClientset()
+	fakeClientset.PrependReactor("list", "persistentvolumes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("list failed")
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.GetPVCLabelsBatch(context.Background(), &GetPVCLabelsBatchRequest{
+		Keys: []*PVCKey{{VolumeHandle: "handle-1"}},
+	})
+	assert.EqualError(t, err, "list failed")
+}
+
+func TestBatchConcurrency(t *testing.T) {
+	assert.Equal(t, defaultBatchConcurrency, batchConcurrency(0))
+	assert.Equal(t, defaultBatchConcurrency, batchConcurrency(-1))
+	assert.Equal(t, 4, batchConcurrency(4))
+	assert.Equal(t, maxBatchConcurrency, batchConcurrency(maxBatchConcurrency))
+	assert.Equal(t, maxBatchConcurrency, batchConcurrency(1<<30))
+}
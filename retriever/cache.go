@@ -0,0 +1,195 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const pvVolumeHandleIndex = "csi.driver/volumeHandle"
+
+// informerCache serves PVC and PV lookups from shared informers
+type informerCache struct {
+	pvcs corelisters.PersistentVolumeClaimLister
+	pvs  cache.Indexer
+}
+
+// StartCache starts PVC and PV informers and serves lookups from them
+// until ctx is done. Lookups fall back to the API server when the cache
+// has not been started or does not know the object yet.
+func (s *MetadataRetrieverClientType) StartCache(ctx context.Context) error {
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return err
+	}
+
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+	pvcInformer := factory.Core().V1().PersistentVolumeClaims()
+	pvInformer := factory.Core().V1().PersistentVolumes()
+	pvcLister := pvcInformer.Lister()
+	err = pvInformer.Informer().AddIndexers(cache.Indexers{
+		pvVolumeHandleIndex: volumeHandleIndexFunc,
+	})
+	if err != nil {
+		return err
+	}
+
+	factory.Start(ctx.Done())
+	for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			factory.Shutdown()
+			return fmt.Errorf("failed to sync informer for %v", informerType)
+		}
+	}
+
+	s.cacheMu.Lock()
+	s.informerCache = &informerCache{
+		pvcs: pvcLister,
+		pvs:  pvInformer.Informer().GetIndexer(),
+	}
+	s.cacheMu.Unlock()
+	log.Info("PVC and PV informer cache started")
+
+	go func() {
+		<-ctx.Done()
+		s.cacheMu.Lock()
+		s.informerCache = nil
+		s.cacheMu.Unlock()
+		factory.Shutdown()
+		log.Info("PVC and PV informer cache stopped")
+	}()
+
+	return nil
+}
+
+func (s *MetadataRetrieverClientType) getInformerCache() *informerCache {
+	s.cacheMu.RLock()
+	defer s.cacheMu.RUnlock()
+	return s.informerCache
+}
+
+func volumeHandleIndexFunc(obj interface{}) ([]string, error) {
+	pv, ok := obj.(*v1.PersistentVolume)
+	if !ok || pv.Spec.CSI == nil {
+		return nil, nil
+	}
+	return []string{volumeHandleKey(pv.Spec.CSI.Driver, pv.Spec.CSI.VolumeHandle)}, nil
+}
+
+// volumeHandleKey identifies a CSI volume. Volume handles are only unique
+// among the volumes of one driver, and driver names cannot hold a slash.
+func volumeHandleKey(driver, volumeHandle string) string {
+	return driver + "/" + volumeHandle
+}
+
+// lookupPVC returns the PVC from the informer cache, falling back to the
+// API server on a cache miss.
+func (s *MetadataRetrieverClientType) lookupPVC(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	namespace, name string,
+) (*v1.PersistentVolumeClaim, error) {
+	if c := s.getInformerCache(); c != nil {
+		pvc, err := c.pvcs.PersistentVolumeClaims(namespace).Get(name)
+		if err == nil {
+			return pvc, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// lookupPVByVolumeHandle returns the PV of a CSI driver with the given
+// volume handle, from the informer cache if it has been started or by
+// listing all PVs.
+func (s *MetadataRetrieverClientType) lookupPVByVolumeHandle(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	driver, volumeHandle string,
+) (*v1.PersistentVolume, error) {
+	if c := s.getInformerCache(); c != nil {
+		objs, err := c.pvs.ByIndex(pvVolumeHandleIndex, volumeHandleKey(driver, volumeHandle))
+		if err != nil {
+			return nil, err
+		}
+		if len(objs) > 0 {
+			return objs[0].(*v1.PersistentVolume), nil
+		}
+	}
+
+	pvs, err := clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pvs.Items {
+		csi := pvs.Items[i].Spec.CSI
+		if csi != nil && csi.Driver == driver && csi.VolumeHandle == volumeHandle {
+			return &pvs.Items[i], nil
+		}
+	}
+	return nil, noPVError(driver, volumeHandle)
+}
+
+func noPVError(driver, volumeHandle string) error {
+	return fmt.Errorf("no PV of driver %s found for volume handle %s", driver, volumeHandle)
+}
+
+// watchErrors returns a watch error handler for an informer that logs the
+// list and watch errors of its reflector with msg, together with a
+// channel that receives the first of them.
+func watchErrors(msg string) (cache.WatchErrorHandler, <-chan error) {
+	errs := make(chan error, 1)
+	return func(_ *cache.Reflector, err error) {
+		log.Warn(msg, err)
+		select {
+		case errs <- err:
+		default:
+		}
+	}, errs
+}
+
+// waitForSync waits until hasSynced reports true or ctx is done. It fails
+// early with the first error received on errs, so that a LIST that keeps
+// failing, for example with Forbidden, is returned instead of blocking
+// until ctx is done.
+func waitForSync(ctx context.Context, hasSynced cache.InformerSynced, errs <-chan error) (bool, error) {
+	syncCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	synced := make(chan bool, 1)
+	go func() {
+		synced <- cache.WaitForCacheSync(syncCtx.Done(), hasSynced)
+	}()
+	select {
+	case ok := <-synced:
+		return ok, nil
+	case err := <-errs:
+		return false, err
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestStartCache(t *testing.T) {
+	pvc := newTestPVC("pvc1", "ns1", map[string]string{"app": "db"})
+	fakeClientset := fake.NewSimpleClientset(pvc, newTestPV("pv1", "csi.dell.com", "handle-1", pvc))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, client.StartCache(ctx))
+	require.NotNil(t, client.getInformerCache())
+
+	// Served from the cache without a GET against the API server.
+	fakeClientset.PrependReactor("get", "persistentvolumeclaims", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("unexpected GET")
+	})
+	got, err := client.lookupPVC(ctx, fakeClientset, "ns1", "pvc1")
+	require.NoError(t, err)
+	assert.Equal(t, "pvc1", got.Name)
+
+	// A cache miss falls back to the API server.
+	_, err = client.lookupPVC(ctx, fakeClientset, "ns1", "pvc2")
+	assert.EqualError(t, err, "unexpected GET")
+
+	pv, err := client.lookupPVByVolumeHandle(ctx, fakeClientset, "csi.dell.com", "handle-1")
+	require.NoError(t, err)
+	assert.Equal(t, "pv1", pv.Name)
+
+	cancel()
+	assert.Eventually(t, func() bool { return client.getInformerCache() == nil }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStartCache_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientsetError)
+	assert.EqualError(t, client.StartCache(context.Background()), "simulated clientset creation error")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client = createTestClient(FakeGetClientset)
+	assert.Error(t, client.StartCache(ctx))
+}
+
+func TestLookupPVByVolumeHandle_NoCache(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		newTestPV("pv0", "csi.example.com", "handle-1", nil),
+		newTestPV("pv1", "csi.dell.com", "handle-1", nil),
+	)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	pv, err := client.lookupPVByVolumeHandle(context.Background(), fakeClientset, "csi.dell.com", "handle-1")
+	require.NoError(t, err)
+	assert.Equal(t, "pv1", pv.Name)
+
+	_, err = client.lookupPVByVolumeHandle(context.Background(), fakeClientset, "csi.dell.com", "handle-2")
+	assert.EqualError(t, err, "no PV of driver csi.dell.com found for volume handle handle-2")
+}
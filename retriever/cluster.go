@@ -0,0 +1,82 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetClusterInfoRequest defines API request type
+type GetClusterInfoRequest struct{}
+
+// GetClusterInfoResponse defines API response type
+type GetClusterInfoResponse struct {
+	// ClusterID is the UID of the kube-system namespace, which is stable
+	// for the lifetime of the cluster.
+	ClusterID string `protobuf:"bytes,1,opt,name=cluster_id,proto3" json:"cluster_id,omitempty"`
+	// ClusterName is the value of X_CSI_RETRIEVER_CLUSTER_NAME, if set.
+	ClusterName       string `protobuf:"bytes,2,opt,name=cluster_name,proto3" json:"cluster_name,omitempty"`
+	KubernetesVersion string `protobuf:"bytes,3,opt,name=kubernetes_version,proto3" json:"kubernetes_version,omitempty"`
+	Platform          string `protobuf:"bytes,4,opt,name=platform,proto3" json:"platform,omitempty"`
+}
+
+// GetClusterInfo returns the identity and Kubernetes version of the
+// cluster. The first successful answer is cached for the lifetime of the
+// client.
+func (s *MetadataRetrieverClientType) GetClusterInfo(
+	ctx context.Context,
+	_ *GetClusterInfoRequest) (
+	*GetClusterInfoResponse, error,
+) {
+	s.clusterInfoMu.Lock()
+	defer s.clusterInfoMu.Unlock()
+	if s.clusterInfo != nil {
+		info := *s.clusterInfo
+		return &info, nil
+	}
+	log.Info("Get cluster info")
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, metav1.NamespaceSystem, metav1.GetOptions{})
+	if err != nil {
+		log.Error("Error retrieving kube-system namespace: ", err)
+		return nil, err
+	}
+
+	version, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		log.Error("Error retrieving server version: ", err)
+		return nil, err
+	}
+
+	s.clusterInfo = &GetClusterInfoResponse{
+		ClusterID:         string(ns.UID),
+		ClusterName:       s.clusterName,
+		KubernetesVersion: version.GitVersion,
+		Platform:          version.Platform,
+	}
+	info := *s.clusterInfo
+	return &info, nil
+}
@@ -0,0 +1,81 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetClusterInfo(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: "uid-kube-system"},
+	})
+	fakeClientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{
+		GitVersion: "v1.34.2",
+		Platform:   "linux/amd64",
+	}
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.clusterName = "prod-east"
+
+	resp, err := client.GetClusterInfo(context.Background(), &GetClusterInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &GetClusterInfoResponse{
+		ClusterID:         "uid-kube-system",
+		ClusterName:       "prod-east",
+		KubernetesVersion: "v1.34.2",
+		Platform:          "linux/amd64",
+	}, resp)
+
+	// Later calls are answered from the cache.
+	client.getClientset = FakeGetClientsetError
+	resp.ClusterID = "modified by caller"
+	resp, err = client.GetClusterInfo(context.Background(), &GetClusterInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "uid-kube-system", resp.ClusterID)
+}
+
+func TestGetClusterInfo_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientsetError)
+	_, err := client.GetClusterInfo(context.Background(), &GetClusterInfoRequest{})
+	assert.EqualError(t, err, "simulated clientset creation error")
+
+	client = createTestClient(FakeGetClientset)
+	_, err = client.GetClusterInfo(context.Background(), &GetClusterInfoRequest{})
+	assert.Contains(t, err.Error(), "not found")
+	assert.Nil(t, client.clusterInfo)
+
+	fakeClientset := fake.NewSimpleClientset(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})
+	fakeClientset.PrependReactor("get", "version", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("version failed")
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.GetClusterInfo(context.Background(), &GetClusterInfoRequest{})
+	assert.EqualError(t, err, "version failed")
+}
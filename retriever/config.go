@@ -0,0 +1,100 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the server-side configuration of the retriever, read from the
+// YAML or JSON file named by X_CSI_RETRIEVER_CONFIG_FILE.
+type Config struct {
+	// Filter is the key filter applied to requests that do not set their
+	// own.
+	Filter *KeyFilter `json:"filter,omitempty"`
+	// SanitizationProfiles are the profiles requests may select by name.
+	SanitizationProfiles map[string]*SanitizationProfile `json:"sanitizationProfiles,omitempty"`
+	// DefaultSanitizationProfile is applied to requests that do not
+	// select a profile.
+	DefaultSanitizationProfile string `json:"defaultSanitizationProfile,omitempty"`
+	// Templates are named text/template templates rendered against the
+	// TemplateData of a PVC.
+	Templates map[string]string `json:"templates,omitempty"`
+	// Redaction drops or masks sensitive labels and annotations in every
+	// response and masks sensitive values in log messages.
+	Redaction *Redaction `json:"redaction,omitempty"`
+
+	filter     *keyFilter
+	sanitizers map[string]*sanitizer
+	templates  map[string]*template.Template
+	redactor   *redactor
+}
+
+// LoadConfig reads and validates the configuration file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	if err := config.compile(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	return config, nil
+}
+
+// compile validates the configuration and prepares it for use.
+func (c *Config) compile() error {
+	var err error
+	if c.filter, err = compileKeyFilter(c.Filter); err != nil {
+		return fmt.Errorf("filter: %v", err)
+	}
+	c.sanitizers = make(map[string]*sanitizer, len(c.SanitizationProfiles))
+	for name, profile := range c.SanitizationProfiles {
+		if c.sanitizers[name], err = compileSanitizationProfile(profile); err != nil {
+			return fmt.Errorf("sanitization profile %s: %v", name, err)
+		}
+	}
+	if p := c.DefaultSanitizationProfile; p != "" && c.sanitizers[p] == nil {
+		return fmt.Errorf("default sanitization profile %s is not defined", p)
+	}
+	if c.templates, err = compileTemplates(c.Templates); err != nil {
+		return fmt.Errorf("templates: %v", err)
+	}
+	if c.redactor, err = compileRedaction(c.Redaction); err != nil {
+		return fmt.Errorf("redaction: %v", err)
+	}
+	return nil
+}
+
+// loadConfigFromEnv loads the configuration file named by
+// X_CSI_RETRIEVER_CONFIG_FILE, or returns an empty configuration if it is
+// not set.
+func loadConfigFromEnv() (*Config, error) {
+	path := os.Getenv(EnvVarConfigFile)
+	if path == "" {
+		return &Config{}, nil
+	}
+	return LoadConfig(path)
+}
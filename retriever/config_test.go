@@ -0,0 +1,66 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeTestConfig(t, `
+filter:
+  include:
+    prefixes: ["storage.example.com/"]
+  exclude:
+    regexes: ["owner$"]
+  stripPrefix: true
+`)
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"storage.example.com/"}, config.Filter.Include.Prefixes)
+	assert.Equal(t, map[string]string{"tier": "gold"}, config.filter.apply(testFilterLabels))
+
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	_, err = LoadConfig(writeTestConfig(t, "filter:\n  unknown: true\n"))
+	assert.ErrorContains(t, err, "invalid config file")
+
+	_, err = LoadConfig(writeTestConfig(t, "filter:\n  exclude:\n    regexes: [\"(\"]\n"))
+	assert.ErrorContains(t, err, "filter: invalid key regex")
+}
+
+func TestNewMetadataRetrieverClient_ConfigError(t *testing.T) {
+	t.Setenv(EnvVarConfigFile, filepath.Join(t.TempDir(), "missing.yaml"))
+	client := NewMetadataRetrieverClient(nil, 0)
+	client.getClientset = FakeGetClientset
+
+	_, err := client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1"})
+	assert.ErrorContains(t, err, "missing.yaml")
+}
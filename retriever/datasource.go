@@ -0,0 +1,210 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxDataSourceDepth caps the number of hops GetPVCDataSource will walk.
+const maxDataSourceDepth = 10
+
+// GetPVCDataSourceRequest defines API request type
+type GetPVCDataSourceRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// MaxDepth is the number of hops to walk. Defaults to 1, the direct
+	// data source only.
+	MaxDepth int32 `protobuf:"varint,3,opt,name=max_depth,proto3" json:"max_depth,omitempty"`
+}
+
+// DataSourceMetadata describes one hop of a PVC's lineage
+type DataSourceMetadata struct {
+	Kind      string            `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	APIGroup  string            `protobuf:"bytes,2,opt,name=api_group,proto3" json:"api_group,omitempty"`
+	Name      string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace string            `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID       string            `protobuf:"bytes,5,opt,name=uid,proto3" json:"uid,omitempty"`
+	Labels    map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Missing is set when the source object no longer exists or is of a
+	// kind the retriever cannot resolve. The walk ends at such a hop.
+	Missing bool `protobuf:"varint,7,opt,name=missing,proto3" json:"missing,omitempty"`
+}
+
+// GetPVCDataSourceResponse defines API response type. Sources is ordered
+// from the direct data source to the most distant ancestor and is empty
+// for a PVC that was not created from a data source.
+type GetPVCDataSourceResponse struct {
+	Sources []*DataSourceMetadata `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+// GetPVCDataSource resolves the snapshot or PVC a PVC was created from,
+// following spec.dataSourceRef (or spec.dataSource) across namespaces and
+// through VolumeSnapshots to their source PVCs up to MaxDepth hops.
+func (s *MetadataRetrieverClientType) GetPVCDataSource(
+	ctx context.Context,
+	req *GetPVCDataSourceRequest) (
+	*GetPVCDataSourceResponse, error,
+) {
+	log.Infof("Get PVC data source for %s in namespace %s", req.Name, req.NameSpace)
+	if req.Name == "" {
+		return nil, errors.New("PVC Name cannot be empty")
+	}
+	maxDepth := int(req.MaxDepth)
+	if maxDepth <= 0 {
+		maxDepth = 1
+	}
+	if maxDepth > maxDataSourceDepth {
+		maxDepth = maxDataSourceDepth
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	pvc, err := s.lookupPVC(ctx, clientset, req.NameSpace, req.Name)
+	if err != nil {
+		log.Error("Error retrieving PVC info: ", err)
+		return nil, err
+	}
+	if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+		log.Error("Error checking PVC access: ", err)
+		return nil, err
+	}
+
+	resp := &GetPVCDataSourceResponse{
+		Sources: []*DataSourceMetadata{},
+	}
+	for pvc != nil && len(resp.Sources) < maxDepth {
+		source := pvcDataSource(pvc)
+		if source == nil {
+			break
+		}
+		resp.Sources = append(resp.Sources, source)
+
+		switch {
+		case source.Kind == "PersistentVolumeClaim" && source.APIGroup == "":
+			pvc, err = s.resolvePVCSource(ctx, clientset, source)
+		case source.Kind == "VolumeSnapshot" && source.APIGroup == volumeSnapshotGVR.Group:
+			var snapshotSource *DataSourceMetadata
+			pvc = nil
+			snapshotSource, err = s.resolveSnapshotSource(ctx, source)
+			if err == nil && snapshotSource != nil && len(resp.Sources) < maxDepth {
+				resp.Sources = append(resp.Sources, snapshotSource)
+				pvc, err = s.resolvePVCSource(ctx, clientset, snapshotSource)
+			}
+		default:
+			source.Missing = true
+			pvc = nil
+		}
+		if err != nil {
+			log.Error("Error retrieving data source info: ", err)
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// pvcDataSource returns the data source of pvc, preferring dataSourceRef
+// as it is the only field that can point into another namespace.
+func pvcDataSource(pvc *v1.PersistentVolumeClaim) *DataSourceMetadata {
+	source := &DataSourceMetadata{NameSpace: pvc.Namespace}
+	switch {
+	case pvc.Spec.DataSourceRef != nil:
+		ref := pvc.Spec.DataSourceRef
+		source.Kind = ref.Kind
+		source.Name = ref.Name
+		if ref.APIGroup != nil {
+			source.APIGroup = *ref.APIGroup
+		}
+		if ref.Namespace != nil && *ref.Namespace != "" {
+			source.NameSpace = *ref.Namespace
+		}
+	case pvc.Spec.DataSource != nil:
+		ref := pvc.Spec.DataSource
+		source.Kind = ref.Kind
+		source.Name = ref.Name
+		if ref.APIGroup != nil {
+			source.APIGroup = *ref.APIGroup
+		}
+	default:
+		return nil
+	}
+	return source
+}
+
+// resolvePVCSource fills in source from the cloned PVC and returns it, or
+// nil if it no longer exists.
+func (s *MetadataRetrieverClientType) resolvePVCSource(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	source *DataSourceMetadata,
+) (*v1.PersistentVolumeClaim, error) {
+	pvc, err := s.lookupPVC(ctx, clientset, source.NameSpace, source.Name)
+	if apierrors.IsNotFound(err) {
+		source.Missing = true
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	source.UID = string(pvc.UID)
+	source.Labels = s.redactMap(pvc.Labels)
+	return pvc, nil
+}
+
+// resolveSnapshotSource fills in source from the VolumeSnapshot and
+// returns the snapshot's own source PVC as the next hop, if it has one. A
+// snapshot is also reported as missing when the snapshot CRDs are not
+// installed.
+func (s *MetadataRetrieverClientType) resolveSnapshotSource(
+	ctx context.Context,
+	source *DataSourceMetadata,
+) (*DataSourceMetadata, error) {
+	snap, err := s.getDynamicObject(ctx, volumeSnapshotGVR, source.NameSpace, source.Name)
+	if apierrors.IsNotFound(err) || status.Code(err) == codes.Unimplemented {
+		source.Missing = true
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	source.UID = string(snap.GetUID())
+	source.Labels = s.redactMap(snap.GetLabels())
+
+	pvcName, _, _ := unstructured.NestedString(snap.Object, "spec", "source", "persistentVolumeClaimName")
+	if pvcName == "" {
+		return nil, nil
+	}
+	return &DataSourceMetadata{
+		Kind:      "PersistentVolumeClaim",
+		Name:      pvcName,
+		NameSpace: snap.GetNamespace(),
+	}, nil
+}
@@ -0,0 +1,123 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func withDataSourceRef(pvc *v1.PersistentVolumeClaim, apiGroup, kind, name, namespace string) *v1.PersistentVolumeClaim {
+	ref := &v1.TypedObjectReference{Kind: kind, Name: name}
+	if apiGroup != "" {
+		ref.APIGroup = &apiGroup
+	}
+	if namespace != "" {
+		ref.Namespace = &namespace
+	}
+	pvc.Spec.DataSourceRef = ref
+	return pvc
+}
+
+func TestGetPVCDataSource(t *testing.T) {
+	// restored (ns1) <- snap1 (ns2) <- clone (ns2) <- origin (ns2)
+	restored := withDataSourceRef(newTestPVC("restored", "ns1", nil), "snapshot.storage.k8s.io", "VolumeSnapshot", "snap1", "ns2")
+	clone := newTestPVC("clone", "ns2", map[string]string{"gen": "2"})
+	clone.Spec.DataSource = &v1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "origin"}
+	origin := newTestPVC("origin", "ns2", map[string]string{"gen": "1"})
+
+	fakeClientset := fake.NewSimpleClientset(restored, clone, origin)
+	fakeClientset.Resources = []*metav1.APIResourceList{snapshotAPIResources}
+	client := createTestDynamicClient(fakeClientset, newTestVolumeSnapshot("snap1", "ns2", "clone"))
+
+	resp, err := client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{Name: "restored", NameSpace: "ns1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Sources, 1)
+	assert.Equal(t, &DataSourceMetadata{
+		Kind:      "VolumeSnapshot",
+		APIGroup:  "snapshot.storage.k8s.io",
+		Name:      "snap1",
+		NameSpace: "ns2",
+		UID:       "uid-snap1",
+		Labels:    map[string]string{"backup": "daily"},
+	}, resp.Sources[0])
+
+	resp, err = client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{Name: "restored", NameSpace: "ns1", MaxDepth: 5})
+	require.NoError(t, err)
+	require.Len(t, resp.Sources, 3)
+	assert.Equal(t, "clone", resp.Sources[1].Name)
+	assert.Equal(t, "uid-clone", resp.Sources[1].UID)
+	assert.Equal(t, "origin", resp.Sources[2].Name)
+	assert.Equal(t, map[string]string{"gen": "1"}, resp.Sources[2].Labels)
+
+	resp, err = client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{Name: "restored", NameSpace: "ns1", MaxDepth: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.Sources, 2)
+	assert.Equal(t, "clone", resp.Sources[1].Name)
+
+	resp, err = client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{Name: "origin", NameSpace: "ns2"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Sources)
+}
+
+func TestGetPVCDataSource_Missing(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		withDataSourceRef(newTestPVC("from-deleted-pvc", "ns1", nil), "", "PersistentVolumeClaim", "gone", ""),
+		withDataSourceRef(newTestPVC("from-deleted-snap", "ns1", nil), "snapshot.storage.k8s.io", "VolumeSnapshot", "gone", ""),
+		withDataSourceRef(newTestPVC("from-populator", "ns1", nil), "populator.example.com", "Backup", "b1", ""),
+	)
+	fakeClientset.Resources = []*metav1.APIResourceList{snapshotAPIResources}
+	client := createTestDynamicClient(fakeClientset)
+
+	for _, name := range []string{"from-deleted-pvc", "from-deleted-snap", "from-populator"} {
+		resp, err := client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{Name: name, NameSpace: "ns1", MaxDepth: 3})
+		require.NoError(t, err, name)
+		require.Len(t, resp.Sources, 1, name)
+		assert.True(t, resp.Sources[0].Missing, name)
+		assert.Equal(t, "ns1", resp.Sources[0].NameSpace, name)
+	}
+
+	// The snapshot API is not installed: the walk stops at the snapshot.
+	fakeClientset = fake.NewSimpleClientset(
+		withDataSourceRef(newTestPVC("restored", "ns1", nil), "snapshot.storage.k8s.io", "VolumeSnapshot", "snap1", ""),
+	)
+	client = createTestDynamicClient(fakeClientset)
+	resp, err := client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{Name: "restored", NameSpace: "ns1", MaxDepth: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.Sources, 1)
+	assert.True(t, resp.Sources[0].Missing)
+	assert.Equal(t, "snap1", resp.Sources[0].Name)
+}
+
+func TestGetPVCDataSource_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{})
+	assert.EqualError(t, err, "PVC Name cannot be empty")
+
+	_, err = client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{Name: "missing", NameSpace: "ns1"})
+	assert.Contains(t, err.Error(), "not found")
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetPVCDataSource(context.Background(), &GetPVCDataSourceRequest{Name: "pvc1"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+}
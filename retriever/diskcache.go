@@ -0,0 +1,319 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultDiskCacheMaxStaleness is the maximum staleness of the metadata
+// served from the disk cache when X_CSI_RETRIEVER_CACHE_MAX_STALENESS is
+// not set.
+const defaultDiskCacheMaxStaleness = time.Hour
+
+// diskCachePruneInterval is how often the entries older than the maximum
+// staleness are deleted.
+const diskCachePruneInterval = 10 * time.Minute
+
+// diskCacheEntry is the last known metadata of a PVC, together with the
+// namespace and the MetadataPolicy rule it was returned with.
+type diskCacheEntry struct {
+	StoredAt  time.Time                 `json:"storedAt"`
+	PVC       *v1.PersistentVolumeClaim `json:"pvc"`
+	Namespace *v1.Namespace             `json:"namespace,omitempty"`
+	Policy    string                    `json:"policy,omitempty"`
+	Rule      int                       `json:"rule,omitempty"`
+}
+
+// diskCache persists the last known metadata of PVCs under dir, so that
+// it can be served while the API server is unreachable. Entries are
+// stored in pvcs/<uid>.json and found by name through names/<hash>, which
+// holds the UID of the PVC. Labels and annotations are redacted before
+// they are written.
+type diskCache struct {
+	dir          string
+	maxStaleness time.Duration
+	redactor     *redactor
+
+	pruneMu   sync.Mutex
+	lastPrune time.Time
+}
+
+// newDiskCacheFromEnv returns the disk cache configured by
+// X_CSI_RETRIEVER_CACHE_DIR, or nil if it is not set or not usable.
+func newDiskCacheFromEnv(r *redactor) *diskCache {
+	dir := os.Getenv(EnvVarCacheDir)
+	if dir == "" {
+		return nil
+	}
+	c := &diskCache{dir: dir, maxStaleness: defaultDiskCacheMaxStaleness, redactor: r}
+	if v := os.Getenv(EnvVarCacheMaxStaleness); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Warnf("Ignoring invalid %s value %q", EnvVarCacheMaxStaleness, v)
+		} else {
+			c.maxStaleness = d
+		}
+	}
+	for _, sub := range []string{"pvcs", "names"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o700); err != nil {
+			log.Error("Error creating disk cache directory, disk cache disabled: ", err)
+			return nil
+		}
+	}
+	c.pruneIfDue()
+	return c
+}
+
+func (c *diskCache) entryPath(uid string) string {
+	return filepath.Join(c.dir, "pvcs", uid+".json")
+}
+
+// namePath returns the path of the name index file of a PVC. The name is
+// hashed as it comes from the request.
+func (c *diskCache) namePath(namespace, name string) string {
+	sum := sha256.Sum256([]byte(namespace + "/" + name))
+	return filepath.Join(c.dir, "names", hex.EncodeToString(sum[:]))
+}
+
+// store saves the metadata of a PVC. Errors are logged, as the cache must
+// not fail the request that fills it.
+func (c *diskCache) store(pvc *v1.PersistentVolumeClaim, namespace *v1.Namespace, rule *policyRule) {
+	if c == nil {
+		return
+	}
+	entry := &diskCacheEntry{
+		StoredAt: time.Now(),
+		PVC: &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        pvc.Name,
+				Namespace:   pvc.Namespace,
+				UID:         pvc.UID,
+				Labels:      c.redactor.apply(pvc.Labels),
+				Annotations: c.redactor.apply(pvc.Annotations),
+			},
+			Spec: v1.PersistentVolumeClaimSpec{
+				StorageClassName: pvc.Spec.StorageClassName,
+				VolumeName:       pvc.Spec.VolumeName,
+			},
+		},
+	}
+	if namespace != nil {
+		entry.Namespace = &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        namespace.Name,
+				Labels:      c.redactor.apply(namespace.Labels),
+				Annotations: c.redactor.apply(namespace.Annotations),
+			},
+		}
+	}
+	if rule != nil {
+		entry.Policy, entry.Rule = rule.policy, rule.index
+	}
+
+	// A PVC that was deleted and recreated leaves the entry of the old UID
+	// behind.
+	namePath := c.namePath(pvc.Namespace, pvc.Name)
+	if old, err := os.ReadFile(namePath); err == nil && string(old) != string(pvc.UID) {
+		c.removeEntry(string(old))
+	}
+
+	data, err := json.Marshal(entry)
+	if err == nil {
+		err = writeFileAtomic(c.entryPath(string(pvc.UID)), data)
+	}
+	if err == nil {
+		err = writeFileAtomic(namePath, []byte(pvc.UID))
+	}
+	if err != nil {
+		log.Warn("Error writing disk cache entry: ", err)
+	}
+	c.pruneIfDue()
+}
+
+// remove deletes the entry of a PVC that no longer exists.
+func (c *diskCache) remove(namespace, name string) {
+	if c == nil {
+		return
+	}
+	namePath := c.namePath(namespace, name)
+	uid, err := os.ReadFile(namePath)
+	if err != nil {
+		return
+	}
+	c.removeEntry(string(uid))
+	if err := os.Remove(namePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn("Error removing disk cache index: ", err)
+	}
+}
+
+func (c *diskCache) removeEntry(uid string) {
+	if filepath.Base(uid) != uid {
+		return
+	}
+	if err := os.Remove(c.entryPath(uid)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Warn("Error removing disk cache entry: ", err)
+	}
+}
+
+// pruneIfDue deletes the files older than the maximum staleness, which can
+// no longer be served, at most once per diskCachePruneInterval.
+func (c *diskCache) pruneIfDue() {
+	c.pruneMu.Lock()
+	defer c.pruneMu.Unlock()
+	if time.Since(c.lastPrune) < diskCachePruneInterval {
+		return
+	}
+	c.lastPrune = time.Now()
+
+	// Entries and their name index are written together, so the file
+	// modification time is the time of the last store.
+	cutoff := time.Now().Add(-c.maxStaleness)
+	for _, sub := range []string{"pvcs", "names"} {
+		dir := filepath.Join(c.dir, sub)
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			log.Warn("Error reading disk cache directory: ", err)
+			continue
+		}
+		for _, f := range files {
+			info, err := f.Info()
+			if err != nil || f.IsDir() || !info.ModTime().Before(cutoff) {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, f.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Warn("Error pruning disk cache: ", err)
+			}
+		}
+	}
+}
+
+// lookup returns the entry of a PVC, or nil if there is none or it is
+// older than the maximum staleness.
+func (c *diskCache) lookup(namespace, name string) *diskCacheEntry {
+	if c == nil {
+		return nil
+	}
+	uid, err := os.ReadFile(c.namePath(namespace, name))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn("Error reading disk cache index: ", err)
+		}
+		return nil
+	}
+	// The UID was written by store, but do not follow a tampered index
+	// out of the cache directory.
+	if filepath.Base(string(uid)) != string(uid) {
+		return nil
+	}
+	data, err := os.ReadFile(c.entryPath(string(uid)))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Warn("Error reading disk cache entry: ", err)
+		}
+		return nil
+	}
+	entry := &diskCacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil || entry.PVC == nil {
+		log.Warn("Ignoring corrupt disk cache entry for UID ", string(uid))
+		return nil
+	}
+	if age := time.Since(entry.StoredAt); age > c.maxStaleness {
+		log.Warnf("Disk cache entry for PVC %s/%s is %v old, beyond %s", namespace, name,
+			age.Round(time.Second), EnvVarCacheMaxStaleness)
+		return nil
+	}
+	return entry
+}
+
+// fromDiskCache returns the last known PVC and the output options it was
+// returned with if err shows that the API server is unreachable and the
+// disk cache holds metadata of the PVC that is not too stale. A request
+// whose ctx is done is not served from the cache. Only PVCs that passed
+// the access check are stored, so it is not repeated.
+func (s *MetadataRetrieverClientType) fromDiskCache(
+	ctx context.Context,
+	namespace, name string,
+	opts *outputOptions,
+	err error,
+) (*v1.PersistentVolumeClaim, *outputOptions, *policyRule, bool) {
+	if s.diskCache == nil || ctx.Err() != nil || !isUnreachable(err) {
+		return nil, nil, nil, false
+	}
+	entry := s.diskCache.lookup(namespace, name)
+	if entry == nil {
+		return nil, nil, nil, false
+	}
+	log.Warnf("API server unreachable, serving PVC %s/%s from the disk cache as of %s",
+		namespace, name, entry.StoredAt.Format(time.RFC3339))
+
+	var rule *policyRule
+	if entry.Policy != "" {
+		if rule = s.policies.rule(entry.Policy, entry.Rule); rule == nil {
+			log.Warnf("MetadataPolicy %s rule %d is gone, serving without it", entry.Policy, entry.Rule)
+		}
+	}
+	return entry.PVC, opts.withRule(rule, entry.Namespace), rule, true
+}
+
+// isUnreachable reports whether err shows that the API server could not
+// be reached or could not answer. Cancelled requests and expired deadlines
+// of the caller, which client-go wraps in net errors too, are not.
+func isUnreachable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err)
+}
+
+// writeFileAtomic writes data to a temporary file renamed to path, so that
+// readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
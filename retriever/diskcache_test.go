@@ -0,0 +1,265 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// failPVCGets makes the PVC reads of fakeClientset fail with err.
+func failPVCGets(fakeClientset *fake.Clientset, err error) {
+	fakeClientset.PrependReactor("get", "persistentvolumeclaims", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, err
+	})
+}
+
+func TestNewDiskCacheFromEnv(t *testing.T) {
+	t.Setenv(EnvVarCacheDir, "")
+	assert.Nil(t, newDiskCacheFromEnv(nil))
+
+	dir := t.TempDir()
+	t.Setenv(EnvVarCacheDir, dir)
+	c := newDiskCacheFromEnv(nil)
+	require.NotNil(t, c)
+	assert.Equal(t, defaultDiskCacheMaxStaleness, c.maxStaleness)
+	assert.DirExists(t, filepath.Join(dir, "pvcs"))
+	assert.DirExists(t, filepath.Join(dir, "names"))
+
+	t.Setenv(EnvVarCacheMaxStaleness, "10m")
+	assert.Equal(t, 10*time.Minute, newDiskCacheFromEnv(nil).maxStaleness)
+	t.Setenv(EnvVarCacheMaxStaleness, "-1m")
+	assert.Equal(t, defaultDiskCacheMaxStaleness, newDiskCacheFromEnv(nil).maxStaleness)
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	t.Setenv(EnvVarCacheDir, file)
+	assert.Nil(t, newDiskCacheFromEnv(nil))
+}
+
+func TestDiskCache(t *testing.T) {
+	c := &diskCache{dir: t.TempDir(), maxStaleness: time.Hour}
+	require.NoError(t, os.MkdirAll(filepath.Join(c.dir, "pvcs"), 0o700))
+	require.NoError(t, os.MkdirAll(filepath.Join(c.dir, "names"), 0o700))
+
+	pvc := newTestPVC("pvc1", "ns1", map[string]string{"app": "db"})
+	pvc.Spec.VolumeName = "pvc-1234"
+	ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"tier": "prod"}}}
+	c.store(pvc, ns, &policyRule{policy: "p", index: 2})
+
+	entry := c.lookup("ns1", "pvc1")
+	require.NotNil(t, entry)
+	assert.Equal(t, pvc.UID, entry.PVC.UID)
+	assert.Equal(t, map[string]string{"app": "db"}, entry.PVC.Labels)
+	assert.Equal(t, "pvc-1234", entry.PVC.Spec.VolumeName)
+	assert.Equal(t, map[string]string{"tier": "prod"}, entry.Namespace.Labels)
+	assert.Equal(t, "p", entry.Policy)
+	assert.Equal(t, 2, entry.Rule)
+	assert.FileExists(t, filepath.Join(c.dir, "pvcs", "uid-pvc1.json"))
+
+	assert.Nil(t, c.lookup("ns1", "missing"))
+	assert.Nil(t, c.lookup("ns1", "../pvc1"))
+
+	// A recreated PVC replaces the name index.
+	recreated := newTestPVC("pvc1", "ns1", map[string]string{"app": "web"})
+	recreated.UID = "uid-recreated"
+	c.store(recreated, nil, nil)
+	entry = c.lookup("ns1", "pvc1")
+	require.NotNil(t, entry)
+	assert.Equal(t, map[string]string{"app": "web"}, entry.PVC.Labels)
+	assert.Nil(t, entry.Namespace)
+
+	c.maxStaleness = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	assert.Nil(t, c.lookup("ns1", "pvc1"))
+
+	require.NoError(t, os.WriteFile(c.entryPath("uid-recreated"), []byte("{"), 0o600))
+	c.maxStaleness = time.Hour
+	assert.Nil(t, c.lookup("ns1", "pvc1"))
+
+	var nilCache *diskCache
+	nilCache.store(pvc, nil, nil)
+	assert.Nil(t, nilCache.lookup("ns1", "pvc1"))
+}
+
+func TestDiskCache_RedactionAndPruning(t *testing.T) {
+	t.Setenv(EnvVarCacheDir, t.TempDir())
+	c := newDiskCacheFromEnv(testRedactionConfig(t).redactor)
+	require.NotNil(t, c)
+
+	pvc := newTestPVC("pvc1", "ns1", map[string]string{"app": "db"})
+	pvc.Annotations = map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": "{...}",
+		"owner": "jane.doe@example.com",
+	}
+	ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"secret.team": "storage"}}}
+	c.store(pvc, ns, nil)
+
+	data, err := os.ReadFile(c.entryPath("uid-pvc1"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "last-applied-configuration")
+	assert.NotContains(t, string(data), "jane.doe")
+	assert.NotContains(t, string(data), "storage")
+	entry := c.lookup("ns1", "pvc1")
+	require.NotNil(t, entry)
+	assert.Equal(t, map[string]string{"owner": "REDACTED"}, entry.PVC.Annotations)
+
+	// Storing a recreated PVC deletes the entry of the old one.
+	recreated := newTestPVC("pvc1", "ns1", nil)
+	recreated.UID = "uid-recreated"
+	c.store(recreated, nil, nil)
+	assert.NoFileExists(t, c.entryPath("uid-pvc1"))
+	assert.FileExists(t, c.entryPath("uid-recreated"))
+
+	// A deleted PVC is removed.
+	c.remove("ns1", "pvc1")
+	assert.NoFileExists(t, c.entryPath("uid-recreated"))
+	assert.NoFileExists(t, c.namePath("ns1", "pvc1"))
+	c.remove("ns1", "pvc1")
+
+	// Files older than the maximum staleness are pruned.
+	c.store(pvc, nil, nil)
+	old := time.Now().Add(-2 * c.maxStaleness)
+	require.NoError(t, os.Chtimes(c.entryPath("uid-pvc1"), old, old))
+	require.NoError(t, os.Chtimes(c.namePath("ns1", "pvc1"), old, old))
+	fresh := newTestPVC("pvc2", "ns1", nil)
+	c.store(fresh, nil, nil)
+	c.lastPrune = time.Time{}
+	c.pruneIfDue()
+	assert.NoFileExists(t, c.entryPath("uid-pvc1"))
+	assert.NoFileExists(t, c.namePath("ns1", "pvc1"))
+	assert.FileExists(t, c.entryPath("uid-pvc2"))
+	assert.NotNil(t, c.lookup("ns1", "pvc2"))
+}
+
+func TestIsUnreachable(t *testing.T) {
+	gr := v1.Resource("persistentvolumeclaims")
+	assert.True(t, isUnreachable(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.True(t, isUnreachable(apierrors.NewServiceUnavailable("unavailable")))
+	assert.True(t, isUnreachable(apierrors.NewServerTimeout(gr, "get", 1)))
+	assert.True(t, isUnreachable(apierrors.NewTimeoutError("timeout", 1)))
+	assert.False(t, isUnreachable(apierrors.NewNotFound(gr, "pvc1")))
+	assert.False(t, isUnreachable(errors.New("failed")))
+	assert.False(t, isUnreachable(&url.Error{Op: "Get", URL: "https://api", Err: context.Canceled}))
+	assert.False(t, isUnreachable(&url.Error{Op: "Get", URL: "https://api", Err: context.DeadlineExceeded}))
+}
+
+func TestGetPVCLabels_DiskCache(t *testing.T) {
+	t.Setenv(EnvVarCacheDir, t.TempDir())
+	fakeClientset := fake.NewSimpleClientset(newTestPVC("pvc1", "ns1", map[string]string{"app": "db"}))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	ctx := context.Background()
+	req := &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1"}
+
+	resp, err := client.GetPVCLabels(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, resp.Stale)
+
+	failPVCGets(fakeClientset, apierrors.NewServiceUnavailable("unavailable"))
+	resp, err = client.GetPVCLabels(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, resp.Stale)
+	assert.Equal(t, map[string]string{"app": "db"}, resp.Parameters)
+
+	_, err = client.GetPVCLabels(ctx, &GetPVCLabelsRequest{Name: "pvc2", NameSpace: "ns1"})
+	assert.True(t, apierrors.IsServiceUnavailable(err))
+
+	// A cancelled request gets its error, not the cached labels.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.GetPVCLabels(cancelled, req)
+	assert.True(t, apierrors.IsServiceUnavailable(err))
+
+	// Errors other than an unreachable API server are returned as is.
+	fakeClientset.PrependReactor("get", "persistentvolumeclaims", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(v1.Resource("persistentvolumeclaims"), "pvc1")
+	})
+	_, err = client.GetPVCLabels(ctx, req)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Nil(t, client.diskCache.lookup("ns1", "pvc1"))
+}
+
+func TestGetPVCMetadataFromParameters_DiskCache(t *testing.T) {
+	t.Setenv(EnvVarCacheDir, t.TempDir())
+	pvc := newTestPVC("pvc1", "ns1", map[string]string{"app": "db", "helm.sh/chart": "db-1"})
+	pvc.Spec.StorageClassName = stringPtr("gold")
+	fakeClientset := fake.NewSimpleClientset(pvc,
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"team": "storage"}}})
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	setTestPolicy(t, client, "p", map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{
+			"scope":          map[string]interface{}{"storageClasses": []interface{}{"gold"}},
+			"filter":         map[string]interface{}{"exclude": map[string]interface{}{"prefixes": []interface{}{"helm.sh/"}}},
+			"namespaceMerge": "Fill",
+		}},
+	})
+	ctx := context.Background()
+	req := &GetPVCMetadataFromParametersRequest{Parameters: testCreateVolumeParameters()}
+
+	live, err := client.GetPVCMetadataFromParameters(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, live.Stale)
+
+	failPVCGets(fakeClientset, &net.OpError{Op: "dial", Err: errors.New("connection refused")})
+	stale, err := client.GetPVCMetadataFromParameters(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, stale.Stale)
+	assert.Equal(t, map[string]string{"app": "db", "team": "storage"}, stale.Labels)
+	assert.Equal(t, live.Labels, stale.Labels)
+	assert.Equal(t, "gold", stale.StorageClassName)
+	assert.Equal(t, live.UID, stale.UID)
+
+	// A cancelled request gets its error, not the cached metadata.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.GetPVCMetadataFromParameters(cancelled, req)
+	assert.Error(t, err)
+
+	// Templates need the API server and are left out.
+	setTestPolicy(t, client, "p", map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{
+			"templates": map[string]interface{}{"owner": "{{ .Namespace }}"},
+		}},
+	})
+	stale, err = client.GetPVCMetadataFromParameters(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, stale.Stale)
+	assert.Empty(t, stale.Rendered)
+	assert.Equal(t, map[string]string{"app": "db", "helm.sh/chart": "db-1"}, stale.Labels)
+
+	// Without the policy the cached metadata is served unfiltered.
+	client.policies.set("p", nil)
+	stale, err = client.GetPVCMetadataFromParameters(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "db", "helm.sh/chart": "db-1"}, stale.Labels)
+}
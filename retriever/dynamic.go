@@ -0,0 +1,82 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// getDynamicObject gets an object through the dynamic client. If the
+// resource is not served at all, for example because its CRD is not
+// installed, an Unimplemented error is returned instead of NotFound so
+// that callers can tell the two apart and degrade gracefully.
+func (s *MetadataRetrieverClientType) getDynamicObject(
+	ctx context.Context,
+	gvr schema.GroupVersionResource,
+	namespace, name string,
+) (*unstructured.Unstructured, error) {
+	dynamicClient, err := s.getDynamicClient()
+	if err != nil {
+		log.Error("Error creating dynamic client: ", err)
+		return nil, err
+	}
+
+	obj, err := dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if served, derr := s.resourceServed(gvr); derr == nil && !served {
+			return nil, errResourceNotServed(gvr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// resourceServed asks the discovery API whether gvr is served.
+func (s *MetadataRetrieverClientType) resourceServed(gvr schema.GroupVersionResource) (bool, error) {
+	clientset, err := s.getClientset()
+	if err != nil {
+		return false, err
+	}
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == gvr.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func errResourceNotServed(gvr schema.GroupVersionResource) error {
+	return status.Errorf(codes.Unimplemented,
+		"%s.%s/%s is not served by the API server", gvr.Resource, gvr.Group, gvr.Version)
+}
@@ -0,0 +1,87 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDefaultGetDynamicClient(t *testing.T) {
+	restInClusterConfig = mockInClusterConfig
+	defer func() {
+		restInClusterConfig = rest.InClusterConfig
+	}()
+
+	dynamicClient, err := defaultGetDynamicClient()
+	require.NoError(t, err)
+	assert.NotNil(t, dynamicClient)
+
+	restInClusterConfig = mockInClusterConfigError
+	_, err = defaultGetDynamicClient()
+	assert.EqualError(t, err, "mock error")
+}
+
+func TestResourceServed(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset()
+	fakeClientset.Resources = []*metav1.APIResourceList{snapshotAPIResources}
+	client := createTestDynamicClient(fakeClientset)
+
+	served, err := client.resourceServed(volumeSnapshotGVR)
+	require.NoError(t, err)
+	assert.True(t, served)
+
+	gvr := volumeSnapshotGVR
+	gvr.Resource = "volumesnapshotclasses"
+	served, err = client.resourceServed(gvr)
+	require.NoError(t, err)
+	assert.False(t, served)
+
+	gvr.Version = "v1beta1"
+	served, err = client.resourceServed(gvr)
+	require.NoError(t, err)
+	assert.False(t, served)
+
+	fakeClientset.PrependReactor("get", "resource", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("discovery failed")
+	})
+	_, err = client.resourceServed(volumeSnapshotGVR)
+	assert.EqualError(t, err, "discovery failed")
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.resourceServed(volumeSnapshotGVR)
+	assert.Error(t, err)
+}
+
+func TestGetDynamicObject_ClientError(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	client.getDynamicClient = func() (dynamic.Interface, error) {
+		return nil, errors.New("simulated dynamic client creation error")
+	}
+	_, err := client.getDynamicObject(context.Background(), volumeSnapshotGVR, "ns1", "snap1")
+	assert.EqualError(t, err, "simulated dynamic client creation error")
+}
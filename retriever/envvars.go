@@ -0,0 +1,67 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+const (
+	// EnvVarDriverName is the name of the environment variable used to
+	// specify the CSI driver name used when a request does not set one.
+	EnvVarDriverName = "X_CSI_RETRIEVER_DRIVER_NAME"
+
+	// EnvVarNodeName is the name of the environment variable used to
+	// specify the node looked up when a node request does not set one.
+	EnvVarNodeName = "NODE_NAME"
+
+	// EnvVarClusterName is the name of the environment variable used to
+	// specify a cluster name reported by GetClusterInfo.
+	EnvVarClusterName = "X_CSI_RETRIEVER_CLUSTER_NAME"
+
+	// EnvVarObjectAllowlist is the name of the environment variable used to
+	// specify the comma separated group/version/resource entries that
+	// GetObjectMetadata may read, e.g. "v1/secrets,argoproj.io/v1alpha1/applications".
+	EnvVarObjectAllowlist = "X_CSI_RETRIEVER_OBJECT_ALLOWLIST"
+
+	// EnvVarConfigFile is the name of the environment variable used to
+	// specify the path of the YAML configuration file.
+	EnvVarConfigFile = "X_CSI_RETRIEVER_CONFIG_FILE"
+
+	// EnvVarRestrictToDriver is the name of the environment variable used
+	// to only answer for PVCs provisioned by X_CSI_RETRIEVER_DRIVER_NAME.
+	EnvVarRestrictToDriver = "X_CSI_RETRIEVER_RESTRICT_TO_DRIVER"
+
+	// EnvVarAnnotationPrefix is the name of the environment variable used
+	// to specify the reserved prefix of the annotations AnnotateVolume may
+	// set, e.g. "array.dell.com/".
+	EnvVarAnnotationPrefix = "X_CSI_RETRIEVER_ANNOTATION_PREFIX"
+
+	// EnvVarEmitEvents is the name of the environment variable used to
+	// post Kubernetes Events to PVCs on metadata retrieval outcomes.
+	EnvVarEmitEvents = "X_CSI_RETRIEVER_EMIT_EVENTS"
+
+	// EnvVarRBACCheck is the name of the environment variable used to
+	// specify the startup RBAC self-check mode: warn, fail or off.
+	EnvVarRBACCheck = "X_CSI_RETRIEVER_RBAC_CHECK"
+
+	// EnvVarCacheDir is the name of the environment variable used to
+	// specify the directory of the persistent metadata cache, which is
+	// disabled when it is not set.
+	EnvVarCacheDir = "X_CSI_RETRIEVER_CACHE_DIR"
+
+	// EnvVarCacheMaxStaleness is the name of the environment variable used
+	// to specify how old the metadata served from the persistent cache
+	// may be, as a duration such as "30m". It defaults to one hour.
+	EnvVarCacheMaxStaleness = "X_CSI_RETRIEVER_CACHE_MAX_STALENESS"
+)
@@ -0,0 +1,126 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// eventComponent is the source component of the events posted to PVCs.
+const eventComponent = "csi-metadata-retriever"
+
+// Reasons of the events posted to PVCs.
+const (
+	EventReasonMetadataRetrieved = "MetadataRetrieved"
+	EventReasonForbidden         = "MetadataForbidden"
+	EventReasonNotFound          = "MetadataNotFound"
+	EventReasonDenied            = "MetadataDenied"
+	EventReasonFailed            = "MetadataRetrievalFailed"
+)
+
+// eventCorrelatorOptions rate-limit the events of each PVC to a burst of
+// 10, refilled at one every five minutes. Identical events are aggregated
+// by the correlator into a single event with a count.
+var eventCorrelatorOptions = record.CorrelatorOptions{
+	BurstSize: 10,
+	QPS:       float32(1 / (5 * time.Minute).Seconds()),
+}
+
+// eventRecorder returns the recorder of PVC events, starting the event
+// broadcaster on first use, or nil if events are disabled.
+func (s *MetadataRetrieverClientType) eventRecorder(clientset kubernetes.Interface) record.EventRecorder {
+	if !s.emitEvents {
+		return nil
+	}
+	s.eventMu.Lock()
+	defer s.eventMu.Unlock()
+	select {
+	case <-s.stopCh:
+		return nil
+	default:
+	}
+	if s.recorder == nil {
+		s.broadcaster = record.NewBroadcaster(record.WithCorrelatorOptions(eventCorrelatorOptions))
+		s.broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+		s.recorder = s.broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: eventComponent})
+	}
+	return s.recorder
+}
+
+// recordPVCEvent posts a Normal event to the PVC if err is nil and a
+// Warning event describing err otherwise. obj is either the PVC or a
+// reference to it when it could not be read.
+func (s *MetadataRetrieverClientType) recordPVCEvent(clientset kubernetes.Interface, obj runtime.Object, err error) {
+	recorder := s.eventRecorder(clientset)
+	if recorder == nil {
+		return
+	}
+	if err == nil {
+		recorder.Event(obj, v1.EventTypeNormal, EventReasonMetadataRetrieved, "Metadata retrieved")
+		return
+	}
+	recorder.Eventf(obj, v1.EventTypeWarning, eventReason(err), "Failed to retrieve metadata: %v", err)
+}
+
+// eventReason returns the reason of the Warning event describing err.
+func eventReason(err error) string {
+	switch {
+	case apierrors.IsForbidden(err):
+		return EventReasonForbidden
+	case apierrors.IsNotFound(err):
+		return EventReasonNotFound
+	case status.Code(err) == codes.PermissionDenied:
+		return EventReasonDenied
+	}
+	return EventReasonFailed
+}
+
+// pvcReference returns a reference to a PVC that could not be read.
+func pvcReference(namespace, name string) *v1.ObjectReference {
+	return &v1.ObjectReference{
+		Kind:       "PersistentVolumeClaim",
+		APIVersion: "v1",
+		Namespace:  namespace,
+		Name:       name,
+	}
+}
+
+// stopEvents flushes and stops the event broadcaster, if started.
+func (s *MetadataRetrieverClientType) stopEvents() {
+	s.eventMu.Lock()
+	defer s.eventMu.Unlock()
+	if s.broadcaster != nil {
+		s.broadcaster.Shutdown()
+		s.broadcaster = nil
+		s.recorder = nil
+	}
+}
+
+// emitEvents reads X_CSI_RETRIEVER_EMIT_EVENTS.
+func emitEvents() bool {
+	return envBool(EnvVarEmitEvents)
+}
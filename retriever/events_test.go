@@ -0,0 +1,109 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestEventReason(t *testing.T) {
+	gr := v1.Resource("persistentvolumeclaims")
+	assert.Equal(t, EventReasonForbidden, eventReason(apierrors.NewForbidden(gr, "pvc1", errors.New("rbac"))))
+	assert.Equal(t, EventReasonNotFound, eventReason(apierrors.NewNotFound(gr, "pvc1")))
+	assert.Equal(t, EventReasonDenied, eventReason(status.Error(codes.PermissionDenied, "denied")))
+	assert.Equal(t, EventReasonFailed, eventReason(errors.New("failed")))
+}
+
+func TestRecordPVCEvent(t *testing.T) {
+	pvc := newTestPVC("pvc1", "ns1", map[string]string{"app": "db"})
+	fakeClientset := fake.NewSimpleClientset(pvc)
+	fakeClientset.PrependReactor("get", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.GetAction).GetName() == "forbidden" {
+			return true, nil, apierrors.NewForbidden(v1.Resource("persistentvolumeclaims"), "forbidden", errors.New("rbac"))
+		}
+		return false, nil, nil
+	})
+	getClientset := func() (kubernetes.Interface, error) { return fakeClientset, nil }
+	ctx := context.Background()
+
+	// Events are disabled by default.
+	client := createTestClient(getClientset)
+	assert.Nil(t, client.eventRecorder(fakeClientset))
+
+	t.Setenv(EnvVarEmitEvents, "true")
+	client = createTestClient(getClientset)
+	recorder := record.NewFakeRecorder(10)
+	client.recorder = recorder
+
+	_, err := client.GetPVCLabels(ctx, &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Normal MetadataRetrieved Metadata retrieved", <-recorder.Events)
+
+	_, err = client.GetPVCLabels(ctx, &GetPVCLabelsRequest{Name: "forbidden", NameSpace: "ns1"})
+	require.Error(t, err)
+	assert.Contains(t, <-recorder.Events, "Warning MetadataForbidden Failed to retrieve metadata: ")
+
+	_, err = client.GetPVCMetadataFromParameters(ctx, &GetPVCMetadataFromParametersRequest{
+		Parameters: map[string]string{
+			ParameterPVCName:      "missing",
+			ParameterPVCNamespace: "ns1",
+			ParameterPVName:       "pvc-1234",
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, <-recorder.Events, "Warning MetadataNotFound Failed to retrieve metadata: ")
+}
+
+func TestRecordPVCEvent_Broadcaster(t *testing.T) {
+	pvc := newTestPVC("pvc1", "ns1", nil)
+	fakeClientset := fake.NewSimpleClientset(pvc)
+	t.Setenv(EnvVarEmitEvents, "true")
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	_, err := client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1"})
+	require.NoError(t, err)
+
+	var events *v1.EventList
+	require.Eventually(t, func() bool {
+		events, err = fakeClientset.CoreV1().Events("ns1").List(context.Background(), metav1.ListOptions{})
+		return err == nil && len(events.Items) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	event := events.Items[0]
+	assert.Equal(t, "pvc1", event.InvolvedObject.Name)
+	assert.Equal(t, pvc.UID, event.InvolvedObject.UID)
+	assert.Equal(t, EventReasonMetadataRetrieved, event.Reason)
+	assert.Equal(t, eventComponent, event.Source.Component)
+
+	client.Close()
+	assert.Nil(t, client.eventRecorder(fakeClientset))
+}
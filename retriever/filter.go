@@ -0,0 +1,157 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// KeyMatch matches label and annotation keys. A key matches if it equals
+// one of Keys, starts with one of Prefixes or matches one of Regexes.
+type KeyMatch struct {
+	Keys     []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Prefixes []string `protobuf:"bytes,2,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Regexes  []string `protobuf:"bytes,3,rep,name=regexes,proto3" json:"regexes,omitempty"`
+}
+
+// KeyFilter selects the label and annotation keys that are returned. When
+// Include matches anything only the keys it matches are kept, and keys
+// matched by Exclude are always dropped.
+type KeyFilter struct {
+	Include *KeyMatch `protobuf:"bytes,1,opt,name=include,proto3" json:"include,omitempty"`
+	Exclude *KeyMatch `protobuf:"bytes,2,opt,name=exclude,proto3" json:"exclude,omitempty"`
+	// StripPrefix removes the Include prefix a key was matched by from the
+	// returned key. A stripped key never replaces a key that was returned
+	// unchanged.
+	StripPrefix bool `protobuf:"varint,3,opt,name=strip_prefix,proto3" json:"stripPrefix,omitempty"`
+}
+
+// keyMatcher is a compiled KeyMatch
+type keyMatcher struct {
+	keys     map[string]struct{}
+	prefixes []string
+	regexes  []*regexp.Regexp
+}
+
+// keyFilter is a compiled KeyFilter. A nil keyFilter keeps every key.
+type keyFilter struct {
+	include     *keyMatcher
+	exclude     *keyMatcher
+	stripPrefix bool
+}
+
+func compileKeyMatch(m *KeyMatch) (*keyMatcher, error) {
+	if m == nil || len(m.Keys)+len(m.Prefixes)+len(m.Regexes) == 0 {
+		return nil, nil
+	}
+	matcher := &keyMatcher{keys: map[string]struct{}{}}
+	for _, k := range m.Keys {
+		matcher.keys[k] = struct{}{}
+	}
+	matcher.prefixes = append(matcher.prefixes, m.Prefixes...)
+	// Strip the longest prefix when several match.
+	sort.Slice(matcher.prefixes, func(i, j int) bool {
+		return len(matcher.prefixes[i]) > len(matcher.prefixes[j])
+	})
+	for _, expr := range m.Regexes {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key regex %q: %v", expr, err)
+		}
+		matcher.regexes = append(matcher.regexes, re)
+	}
+	return matcher, nil
+}
+
+func compileKeyFilter(f *KeyFilter) (*keyFilter, error) {
+	if f == nil {
+		return nil, nil
+	}
+	include, err := compileKeyMatch(f.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compileKeyMatch(f.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	return &keyFilter{include: include, exclude: exclude, stripPrefix: f.StripPrefix}, nil
+}
+
+// match reports whether key matches and, if it matched by prefix, which
+// prefix.
+func (m *keyMatcher) match(key string) (bool, string) {
+	if _, ok := m.keys[key]; ok {
+		return true, ""
+	}
+	for _, p := range m.prefixes {
+		if strings.HasPrefix(key, p) {
+			return true, p
+		}
+	}
+	for _, re := range m.regexes {
+		if re.MatchString(key) {
+			return true, ""
+		}
+	}
+	return false, ""
+}
+
+// apply returns the entries of m that pass the filter.
+func (f *keyFilter) apply(m map[string]string) map[string]string {
+	if f == nil {
+		return m
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string]string, len(m))
+	stripped := map[string]string{}
+	for _, k := range keys {
+		prefix := ""
+		if f.include != nil {
+			var ok bool
+			if ok, prefix = f.include.match(k); !ok {
+				continue
+			}
+		}
+		if f.exclude != nil {
+			if ok, _ := f.exclude.match(k); ok {
+				continue
+			}
+		}
+		if f.stripPrefix && prefix != "" && len(k) > len(prefix) {
+			if _, ok := stripped[k[len(prefix):]]; !ok {
+				stripped[k[len(prefix):]] = m[k]
+			}
+			continue
+		}
+		out[k] = m[k]
+	}
+	for k, v := range stripped {
+		if _, ok := out[k]; !ok {
+			out[k] = v
+		}
+	}
+	return out
+}
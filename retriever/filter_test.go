@@ -0,0 +1,142 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var testFilterLabels = map[string]string{
+	"app.kubernetes.io/name":       "db",
+	"app.kubernetes.io/managed-by": "Helm",
+	"helm.sh/chart":                "db-1.2.3",
+	"storage.example.com/tier":     "gold",
+	"storage.example.com/owner":    "team-a",
+	"tier":                         "silver",
+	"cost-center":                  "1234",
+}
+
+func TestKeyFilterApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *KeyFilter
+		want   map[string]string
+	}{
+		{
+			name:   "nil filter keeps everything",
+			filter: nil,
+			want:   testFilterLabels,
+		},
+		{
+			name: "exclude by prefix and regex",
+			filter: &KeyFilter{Exclude: &KeyMatch{
+				Prefixes: []string{"app.kubernetes.io/"},
+				Regexes:  []string{`^helm\.sh/`},
+			}},
+			want: map[string]string{
+				"storage.example.com/tier":  "gold",
+				"storage.example.com/owner": "team-a",
+				"tier":                      "silver",
+				"cost-center":               "1234",
+			},
+		},
+		{
+			name: "include exact key and prefix",
+			filter: &KeyFilter{Include: &KeyMatch{
+				Keys:     []string{"cost-center"},
+				Prefixes: []string{"storage.example.com/"},
+			}},
+			want: map[string]string{
+				"storage.example.com/tier":  "gold",
+				"storage.example.com/owner": "team-a",
+				"cost-center":               "1234",
+			},
+		},
+		{
+			name: "exclude wins over include",
+			filter: &KeyFilter{
+				Include: &KeyMatch{Prefixes: []string{"storage.example.com/"}},
+				Exclude: &KeyMatch{Keys: []string{"storage.example.com/owner"}},
+			},
+			want: map[string]string{"storage.example.com/tier": "gold"},
+		},
+		{
+			name: "strip prefix keeps unchanged keys on collision",
+			filter: &KeyFilter{
+				Include:     &KeyMatch{Keys: []string{"tier"}, Prefixes: []string{"storage.example.com/"}},
+				StripPrefix: true,
+			},
+			want: map[string]string{"tier": "silver", "owner": "team-a"},
+		},
+		{
+			name: "strip longest matching prefix",
+			filter: &KeyFilter{
+				Include:     &KeyMatch{Prefixes: []string{"storage.", "storage.example.com/"}},
+				StripPrefix: true,
+			},
+			want: map[string]string{"tier": "gold", "owner": "team-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := compileKeyFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f.apply(testFilterLabels))
+		})
+	}
+
+	_, err := compileKeyFilter(&KeyFilter{Exclude: &KeyMatch{Regexes: []string{"("}}})
+	assert.ErrorContains(t, err, "invalid key regex")
+}
+
+func TestGetPVCLabels_Filter(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(newTestPVC("pvc1", "ns1", testFilterLabels))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.config = &Config{Filter: &KeyFilter{Include: &KeyMatch{Prefixes: []string{"storage.example.com/"}}, StripPrefix: true}}
+	require.NoError(t, client.config.compile())
+
+	resp, err := client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tier": "gold", "owner": "team-a"}, resp.Parameters)
+
+	// A request filter replaces the default one.
+	resp, err = client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{
+		Name: "pvc1", NameSpace: "ns1",
+		Filter: &KeyFilter{Include: &KeyMatch{Keys: []string{"cost-center"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"cost-center": "1234"}, resp.Parameters)
+
+	_, err = client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{
+		Name: "pvc1", NameSpace: "ns1",
+		Filter: &KeyFilter{Include: &KeyMatch{Regexes: []string{"["}}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	batch, err := client.GetPVCLabelsBatch(context.Background(), &GetPVCLabelsBatchRequest{
+		Keys: []*PVCKey{{Name: "pvc1", NameSpace: "ns1"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tier": "gold", "owner": "team-a"}, batch.Results[0].Parameters)
+}
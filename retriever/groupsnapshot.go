@@ -0,0 +1,208 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// volumeGroupSnapshotVersions lists the served versions of the
+// VolumeGroupSnapshot API in order of preference.
+var volumeGroupSnapshotVersions = []string{"v1beta2", "v1beta1", "v1alpha1"}
+
+const volumeGroupSnapshotGroup = "groupsnapshot.storage.k8s.io"
+
+// GetVolumeGroupSnapshotMembersRequest defines API request type. Either
+// Name or LabelSelector must be set; a LabelSelector is resolved directly,
+// for example before the VolumeGroupSnapshot has been created.
+type GetVolumeGroupSnapshotMembersRequest struct {
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace     string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	LabelSelector string `protobuf:"bytes,3,opt,name=label_selector,proto3" json:"label_selector,omitempty"`
+}
+
+// PVCMetadata holds the metadata of a PVC
+type PVCMetadata struct {
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace   string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID         string            `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	VolumeName  string            `protobuf:"bytes,4,opt,name=volume_name,proto3" json:"volume_name,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations map[string]string `protobuf:"bytes,6,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// GetVolumeGroupSnapshotMembersResponse defines API response type. The
+// group fields are only set when the request names a VolumeGroupSnapshot.
+type GetVolumeGroupSnapshotMembersResponse struct {
+	Name                         string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace                    string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID                          string            `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Labels                       map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations                  map[string]string `protobuf:"bytes,5,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	VolumeGroupSnapshotClassName string            `protobuf:"bytes,6,opt,name=volume_group_snapshot_class_name,proto3" json:"volume_group_snapshot_class_name,omitempty"`
+	ContentName                  string            `protobuf:"bytes,7,opt,name=content_name,proto3" json:"content_name,omitempty"`
+	LabelSelector                string            `protobuf:"bytes,8,opt,name=label_selector,proto3" json:"label_selector,omitempty"`
+	Members                      []*PVCMetadata    `protobuf:"bytes,9,rep,name=members,proto3" json:"members,omitempty"`
+}
+
+// GetVolumeGroupSnapshotMembers resolves a VolumeGroupSnapshot, or a label
+// selector, to the PVCs it selects. A pre-provisioned VolumeGroupSnapshot
+// has no selector and is returned without members. Member metadata is
+// shaped by the configured defaults and the matching MetadataPolicy rule;
+// the group's own metadata is only redacted. In restrict-to-driver
+// mode the PVCs of other drivers are left out of the members. If the group
+// snapshot CRDs are not installed an Unimplemented error is returned.
+func (s *MetadataRetrieverClientType) GetVolumeGroupSnapshotMembers(
+	ctx context.Context,
+	req *GetVolumeGroupSnapshotMembersRequest) (
+	*GetVolumeGroupSnapshotMembersResponse, error,
+) {
+	log.Infof("Get VolumeGroupSnapshot members for %q with selector %q in namespace %s",
+		req.Name, req.LabelSelector, req.NameSpace)
+	if req.Name == "" && req.LabelSelector == "" {
+		return nil, errors.New("VolumeGroupSnapshot Name or label selector must be set")
+	}
+
+	resp := &GetVolumeGroupSnapshotMembersResponse{
+		NameSpace:     req.NameSpace,
+		LabelSelector: req.LabelSelector,
+	}
+
+	if req.Name != "" {
+		gvr, err := s.volumeGroupSnapshotGVR()
+		if err != nil {
+			log.Error("Error resolving VolumeGroupSnapshot API version: ", err)
+			return nil, err
+		}
+		group, err := s.getDynamicObject(ctx, gvr, req.NameSpace, req.Name)
+		if err != nil {
+			log.Error("Error retrieving VolumeGroupSnapshot info: ", err)
+			return nil, err
+		}
+
+		resp.Name = group.GetName()
+		resp.UID = string(group.GetUID())
+		resp.Labels = s.redactMap(group.GetLabels())
+		resp.Annotations = s.redactMap(group.GetAnnotations())
+		resp.VolumeGroupSnapshotClassName, _, _ = unstructured.NestedString(group.Object, "spec", "volumeGroupSnapshotClassName")
+		resp.ContentName, _, _ = unstructured.NestedString(group.Object, "status", "boundVolumeGroupSnapshotContentName")
+		if resp.ContentName == "" {
+			resp.ContentName, _, _ = unstructured.NestedString(group.Object, "spec", "source", "volumeGroupSnapshotContentName")
+		}
+
+		selector, found, err := unstructured.NestedMap(group.Object, "spec", "source", "selector")
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			resp.LabelSelector = ""
+			return resp, nil
+		}
+		labelSelector := &metav1.LabelSelector{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selector, labelSelector); err != nil {
+			log.Error("Error parsing VolumeGroupSnapshot selector: ", err)
+			return nil, err
+		}
+		parsed, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			log.Error("Error parsing VolumeGroupSnapshot selector: ", err)
+			return nil, err
+		}
+		resp.LabelSelector = parsed.String()
+	}
+
+	if _, err := labels.Parse(resp.LabelSelector); err != nil {
+		log.Error("Invalid label selector: ", err)
+		return nil, err
+	}
+
+	output, err := s.outputFor(nil, "")
+	if err != nil {
+		log.Error("Error reading output options: ", err)
+		return nil, err
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+	pvcs, err := clientset.CoreV1().PersistentVolumeClaims(req.NameSpace).List(ctx, metav1.ListOptions{
+		LabelSelector: resp.LabelSelector,
+	})
+	if err != nil {
+		log.Error("Error listing PVCs: ", err)
+		return nil, err
+	}
+
+	lookups := newPolicyLookups()
+	resp.Members = make([]*PVCMetadata, 0, len(pvcs.Items))
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+			if status.Code(err) == codes.PermissionDenied {
+				log.Debugf("Skipping member PVC %s/%s: %v", pvc.Namespace, pvc.Name, err)
+				continue
+			}
+			log.Error("Error checking PVC access: ", err)
+			return nil, err
+		}
+		pvcLabels, pvcAnnotations, err := s.pvcMetadata(ctx, clientset, lookups, output, pvc)
+		if err != nil {
+			log.Error("Error matching MetadataPolicy: ", err)
+			return nil, err
+		}
+		resp.Members = append(resp.Members, &PVCMetadata{
+			Name:        pvc.Name,
+			NameSpace:   pvc.Namespace,
+			UID:         string(pvc.UID),
+			VolumeName:  pvc.Spec.VolumeName,
+			Labels:      pvcLabels,
+			Annotations: pvcAnnotations,
+		})
+	}
+
+	return resp, nil
+}
+
+// volumeGroupSnapshotGVR returns the most preferred served version of the
+// VolumeGroupSnapshot API.
+func (s *MetadataRetrieverClientType) volumeGroupSnapshotGVR() (schema.GroupVersionResource, error) {
+	gvr := schema.GroupVersionResource{Group: volumeGroupSnapshotGroup, Resource: "volumegroupsnapshots"}
+	for _, version := range volumeGroupSnapshotVersions {
+		gvr.Version = version
+		served, err := s.resourceServed(gvr)
+		if err != nil {
+			return gvr, err
+		}
+		if served {
+			return gvr, nil
+		}
+	}
+	gvr.Version = volumeGroupSnapshotVersions[0]
+	return gvr, errResourceNotServed(gvr)
+}
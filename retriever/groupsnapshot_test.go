@@ -0,0 +1,115 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestVolumeGroupSnapshot(name, namespace string, source map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "groupsnapshot.storage.k8s.io/v1beta1",
+		"kind":       "VolumeGroupSnapshot",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"uid":       "uid-" + name,
+			"labels":    map[string]interface{}{"cg": "oracle"},
+		},
+		"spec": map[string]interface{}{
+			"volumeGroupSnapshotClassName": "dell-groupsnapclass",
+			"source":                       source,
+		},
+		"status": map[string]interface{}{
+			"boundVolumeGroupSnapshotContentName": "groupsnapcontent-" + name,
+		},
+	}}
+}
+
+func TestGetVolumeGroupSnapshotMembers(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		newTestPVC("data", "ns1", map[string]string{"app": "oracle", "tier": "data"}),
+		newTestPVC("logs", "ns1", map[string]string{"app": "oracle", "tier": "logs"}),
+		newTestPVC("web", "ns1", map[string]string{"app": "web"}),
+	)
+	fakeClientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "groupsnapshot.storage.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "volumegroupsnapshots", Namespaced: true}},
+	}}
+	client := createTestDynamicClient(fakeClientset,
+		newTestVolumeGroupSnapshot("group1", "ns1", map[string]interface{}{
+			"selector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{"app": "oracle"},
+			},
+		}),
+		newTestVolumeGroupSnapshot("static", "ns1", map[string]interface{}{
+			"volumeGroupSnapshotContentName": "pre-provisioned",
+		}),
+	)
+
+	resp, err := client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{Name: "group1", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, "uid-group1", resp.UID)
+	assert.Equal(t, map[string]string{"cg": "oracle"}, resp.Labels)
+	assert.Equal(t, "dell-groupsnapclass", resp.VolumeGroupSnapshotClassName)
+	assert.Equal(t, "groupsnapcontent-group1", resp.ContentName)
+	assert.Equal(t, "app=oracle", resp.LabelSelector)
+	require.Len(t, resp.Members, 2)
+	names := []string{resp.Members[0].Name, resp.Members[1].Name}
+	assert.ElementsMatch(t, []string{"data", "logs"}, names)
+
+	resp, err = client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{LabelSelector: "tier=logs", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Name)
+	require.Len(t, resp.Members, 1)
+	assert.Equal(t, "logs", resp.Members[0].Name)
+	assert.Equal(t, map[string]string{"app": "oracle", "tier": "logs"}, resp.Members[0].Labels)
+
+	resp, err = client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{Name: "static", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, "groupsnapcontent-static", resp.ContentName)
+	assert.Empty(t, resp.LabelSelector)
+	assert.Empty(t, resp.Members)
+}
+
+func TestGetVolumeGroupSnapshotMembers_Errors(t *testing.T) {
+	client := createTestDynamicClient(fake.NewSimpleClientset())
+
+	_, err := client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{})
+	assert.EqualError(t, err, "VolumeGroupSnapshot Name or label selector must be set")
+
+	_, err = client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{Name: "group1", NameSpace: "ns1"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{LabelSelector: "a in (b"})
+	assert.Error(t, err)
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{LabelSelector: "app=oracle"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+	_, err = client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{Name: "group1"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+}
@@ -0,0 +1,172 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ListVolumeMetadataRequest defines API request type
+type ListVolumeMetadataRequest struct {
+	// DriverName selects PVs by spec.csi.driver. Defaults to the value of
+	// X_CSI_RETRIEVER_DRIVER_NAME.
+	DriverName string `protobuf:"bytes,1,opt,name=driver_name,proto3" json:"driver_name,omitempty"`
+	// LabelSelector is applied to the PVs.
+	LabelSelector string `protobuf:"bytes,2,opt,name=label_selector,proto3" json:"label_selector,omitempty"`
+	// PVCLabelSelector is applied to the bound PVCs. PVs without a bound
+	// PVC never match a non-empty PVC selector.
+	PVCLabelSelector string `protobuf:"bytes,3,opt,name=pvc_label_selector,proto3" json:"pvc_label_selector,omitempty"`
+	// Limit is the number of PVs read from the API server per page. As PVs
+	// of other drivers are filtered out, a page may hold fewer volumes.
+	Limit    int64  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Continue string `protobuf:"bytes,5,opt,name=continue,proto3" json:"continue,omitempty"`
+}
+
+// VolumeMetadata holds the metadata of a CSI PV and of its bound PVC
+type VolumeMetadata struct {
+	PVName           string            `protobuf:"bytes,1,opt,name=pv_name,proto3" json:"pv_name,omitempty"`
+	VolumeHandle     string            `protobuf:"bytes,2,opt,name=volume_handle,proto3" json:"volume_handle,omitempty"`
+	StorageClassName string            `protobuf:"bytes,3,opt,name=storage_class_name,proto3" json:"storage_class_name,omitempty"`
+	Phase            string            `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	PVLabels         map[string]string `protobuf:"bytes,5,rep,name=pv_labels,proto3" json:"pv_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PVAnnotations    map[string]string `protobuf:"bytes,6,rep,name=pv_annotations,proto3" json:"pv_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PVCName          string            `protobuf:"bytes,7,opt,name=pvc_name,proto3" json:"pvc_name,omitempty"`
+	PVCNameSpace     string            `protobuf:"bytes,8,opt,name=pvc_namespace,proto3" json:"pvc_namespace,omitempty"`
+	PVCUID           string            `protobuf:"bytes,9,opt,name=pvc_uid,proto3" json:"pvc_uid,omitempty"`
+	PVCLabels        map[string]string `protobuf:"bytes,10,rep,name=pvc_labels,proto3" json:"pvc_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	PVCAnnotations   map[string]string `protobuf:"bytes,11,rep,name=pvc_annotations,proto3" json:"pvc_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// ListVolumeMetadataResponse defines API response type. An empty Continue
+// means there are no more pages.
+type ListVolumeMetadataResponse struct {
+	Volumes  []*VolumeMetadata `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	Continue string            `protobuf:"bytes,2,opt,name=continue,proto3" json:"continue,omitempty"`
+}
+
+// ListVolumeMetadata lists the PVs provisioned by a CSI driver together
+// with the metadata of their bound PVCs, one page at a time. PVC metadata
+// is shaped by the configured defaults and the matching MetadataPolicy
+// rule like GetPVCLabels; PV metadata is only redacted.
+func (s *MetadataRetrieverClientType) ListVolumeMetadata(
+	ctx context.Context,
+	req *ListVolumeMetadataRequest) (
+	*ListVolumeMetadataResponse, error,
+) {
+	driverName := req.DriverName
+	if driverName == "" {
+		driverName = s.driverName
+	}
+	log.Infof("List volume metadata for driver %s", driverName)
+	if driverName == "" {
+		return nil, errors.New("driver name cannot be empty")
+	}
+	if err := s.checkDriverAccess(driverName); err != nil {
+		log.Error("Error checking driver access: ", err)
+		return nil, err
+	}
+
+	pvcSelector, err := labels.Parse(req.PVCLabelSelector)
+	if err != nil {
+		log.Error("Invalid PVC label selector: ", err)
+		return nil, err
+	}
+
+	output, err := s.outputFor(nil, "")
+	if err != nil {
+		log.Error("Error reading output options: ", err)
+		return nil, err
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	pvs, err := clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{
+		LabelSelector: req.LabelSelector,
+		Limit:         req.Limit,
+		Continue:      req.Continue,
+	})
+	if err != nil {
+		log.Error("Error listing PVs: ", err)
+		return nil, err
+	}
+
+	lookups := newPolicyLookups()
+	resp := &ListVolumeMetadataResponse{
+		Volumes:  []*VolumeMetadata{},
+		Continue: pvs.Continue,
+	}
+	for i := range pvs.Items {
+		pv := &pvs.Items[i]
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != driverName {
+			continue
+		}
+
+		volume := &VolumeMetadata{
+			PVName:           pv.Name,
+			VolumeHandle:     pv.Spec.CSI.VolumeHandle,
+			StorageClassName: pv.Spec.StorageClassName,
+			Phase:            string(pv.Status.Phase),
+			PVLabels:         s.redactMap(pv.Labels),
+			PVAnnotations:    s.redactMap(pv.Annotations),
+		}
+
+		var pvc *v1.PersistentVolumeClaim
+		if ref := pv.Spec.ClaimRef; ref != nil {
+			volume.PVCName = ref.Name
+			volume.PVCNameSpace = ref.Namespace
+			pvc, err = s.lookupPVC(ctx, clientset, ref.Namespace, ref.Name)
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					log.Error("Error retrieving PVC info: ", err)
+					return nil, err
+				}
+				pvc = nil
+			}
+			if pvc != nil && ref.UID != "" && pvc.UID != ref.UID {
+				// The claim was deleted and recreated; it is not bound here.
+				pvc = nil
+			}
+		}
+
+		if pvc != nil {
+			volume.PVCUID = string(pvc.UID)
+			volume.PVCLabels, volume.PVCAnnotations, err = s.pvcMetadata(ctx, clientset, lookups, output, pvc)
+			if err != nil {
+				log.Error("Error matching MetadataPolicy: ", err)
+				return nil, err
+			}
+		}
+		if !pvcSelector.Empty() && (pvc == nil || !pvcSelector.Matches(labels.Set(pvc.Labels))) {
+			continue
+		}
+
+		resp.Volumes = append(resp.Volumes, volume)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,127 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestListVolumeMetadata(t *testing.T) {
+	pvc1 := newTestPVC("pvc1", "ns1", map[string]string{"app": "db"})
+	pvc2 := newTestPVC("pvc2", "ns1", map[string]string{"app": "web"})
+	recreated := newTestPVC("pvc3", "ns1", nil)
+	pv3 := newTestPV("pv3", "csi.dell.com", "handle-3", recreated)
+	pv3.Spec.ClaimRef.UID = "old-uid"
+	fakeClientset := fake.NewSimpleClientset(
+		pvc1, pvc2, recreated,
+		newTestPV("pv1", "csi.dell.com", "handle-1", pvc1),
+		newTestPV("pv2", "csi.dell.com", "handle-2", pvc2),
+		pv3,
+		newTestPV("pv4", "csi.dell.com", "handle-4", newTestPVC("gone", "ns1", nil)),
+		newTestPV("other", "other.csi.io", "handle-x", nil),
+	)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.driverName = "csi.dell.com"
+
+	resp, err := client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Volumes, 4)
+
+	byName := map[string]*VolumeMetadata{}
+	for _, v := range resp.Volumes {
+		byName[v.PVName] = v
+	}
+	assert.Equal(t, "handle-1", byName["pv1"].VolumeHandle)
+	assert.Equal(t, "uid-pvc1", byName["pv1"].PVCUID)
+	assert.Equal(t, map[string]string{"app": "db"}, byName["pv1"].PVCLabels)
+	assert.Equal(t, "pvc3", byName["pv3"].PVCName)
+	assert.Empty(t, byName["pv3"].PVCUID)
+	assert.Equal(t, "gone", byName["pv4"].PVCName)
+	assert.Empty(t, byName["pv4"].PVCUID)
+
+	resp, err = client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{
+		DriverName:       "csi.dell.com",
+		PVCLabelSelector: "app=web",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Volumes, 1)
+	assert.Equal(t, "pv2", resp.Volumes[0].PVName)
+}
+
+func TestListVolumeMetadata_Continue(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset()
+	fakeClientset.PrependReactor("list", "persistentvolumes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		opts := action.(k8stesting.ListActionImpl).ListOptions
+		assert.Equal(t, int64(10), opts.Limit)
+		assert.Equal(t, "token-1", opts.Continue)
+		list := &v1.PersistentVolumeList{}
+		list.Continue = "token-2"
+		return true, list, nil
+	})
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	resp, err := client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{
+		DriverName: "csi.dell.com",
+		Limit:      10,
+		Continue:   "token-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", resp.Continue)
+	assert.Empty(t, resp.Volumes)
+}
+
+func TestListVolumeMetadata_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{})
+	assert.EqualError(t, err, "driver name cannot be empty")
+
+	_, err = client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{
+		DriverName:       "csi.dell.com",
+		PVCLabelSelector: "a in (b",
+	})
+	assert.Error(t, err)
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{DriverName: "csi.dell.com"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+
+	fakeClientset := fake.NewSimpleClientset(newTestPV("pv1", "csi.dell.com", "handle-1", newTestPVC("pvc1", "ns1", nil)))
+	fakeClientset.PrependReactor("get", "persistentvolumeclaims", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("get failed")
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{DriverName: "csi.dell.com"})
+	assert.EqualError(t, err, "get failed")
+
+	fakeClientset = fake.NewSimpleClientset()
+	fakeClientset.PrependReactor("list", "persistentvolumes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("list failed")
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{DriverName: "csi.dell.com"})
+	assert.EqualError(t, err, "list failed")
+}
@@ -0,0 +1,217 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	labelZone             = "topology.kubernetes.io/zone"
+	labelRegion           = "topology.kubernetes.io/region"
+	labelZoneDeprecated   = "failure-domain.beta.kubernetes.io/zone"
+	labelRegionDeprecated = "failure-domain.beta.kubernetes.io/region"
+)
+
+// GetNodeMetadataRequest defines API request type
+type GetNodeMetadataRequest struct {
+	// Name of the node. Defaults to the value of NODE_NAME.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+// GetNodeMetadataResponse defines API response type
+type GetNodeMetadataResponse struct {
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	UID         string            `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Zone        string            `protobuf:"bytes,5,opt,name=zone,proto3" json:"zone,omitempty"`
+	Region      string            `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	// TopologyLabels holds the well-known topology labels and the labels
+	// prefixed with the configured driver name.
+	TopologyLabels map[string]string `protobuf:"bytes,7,rep,name=topology_labels,proto3" json:"topology_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Allocatable    map[string]string `protobuf:"bytes,8,rep,name=allocatable,proto3" json:"allocatable,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// GetNodeMetadata gets the labels, topology and allocatable resources of
+// a node. Each node is served from its own informer, which is started on
+// first use and runs until the client is closed or the node is not found.
+func (s *MetadataRetrieverClientType) GetNodeMetadata(
+	ctx context.Context,
+	req *GetNodeMetadataRequest) (
+	*GetNodeMetadataResponse, error,
+) {
+	name := req.Name
+	if name == "" {
+		name = os.Getenv(EnvVarNodeName)
+	}
+	log.Infof("Get node metadata for %s", name)
+	if name == "" {
+		return nil, errors.New("Node Name cannot be empty")
+	}
+
+	ni, err := s.syncedNodeInformer(ctx, name)
+	if err != nil {
+		log.Error("Error starting node informer: ", err)
+		return nil, err
+	}
+
+	node, err := ni.lister.Get(name)
+	if apierrors.IsNotFound(err) {
+		s.stopNodeInformer(name, ni)
+	}
+	if err != nil {
+		log.Error("Error retrieving node info: ", err)
+		return nil, err
+	}
+
+	resp := &GetNodeMetadataResponse{
+		Name:           node.Name,
+		UID:            string(node.UID),
+		Labels:         s.redactMap(node.Labels),
+		Annotations:    s.redactMap(node.Annotations),
+		Zone:           firstLabel(node.Labels, labelZone, labelZoneDeprecated),
+		Region:         firstLabel(node.Labels, labelRegion, labelRegionDeprecated),
+		TopologyLabels: map[string]string{},
+		Allocatable:    map[string]string{},
+	}
+	for k, v := range node.Labels {
+		if s.isTopologyLabel(k) {
+			resp.TopologyLabels[k] = v
+		}
+	}
+	for k, v := range node.Status.Allocatable {
+		resp.Allocatable[string(k)] = v.String()
+	}
+
+	return resp, nil
+}
+
+// nodeInformer is an informer that watches a single node
+type nodeInformer struct {
+	lister    corelisters.NodeLister
+	hasSynced cache.InformerSynced
+	listErrs  <-chan error
+	ctx       context.Context
+	stop      context.CancelFunc
+}
+
+// syncedNodeInformer returns the synced informer that watches only the
+// named node, starting it on first use. If the informer fails to sync it
+// is stopped and the list error, if any, is returned.
+func (s *MetadataRetrieverClientType) syncedNodeInformer(ctx context.Context, name string) (*nodeInformer, error) {
+	s.nodeMu.Lock()
+	ni, ok := s.nodeInformers[name]
+	if !ok {
+		clientset, err := s.getClientset()
+		if err != nil {
+			s.nodeMu.Unlock()
+			return nil, err
+		}
+		factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+			}))
+		nodes := factory.Core().V1().Nodes()
+		watchErrorHandler, listErrs := watchErrors("Node watch error: ")
+		if err := nodes.Informer().SetWatchErrorHandler(watchErrorHandler); err != nil {
+			s.nodeMu.Unlock()
+			return nil, err
+		}
+		informerCtx, stop := context.WithCancel(context.Background())
+		ni = &nodeInformer{
+			lister:    nodes.Lister(),
+			hasSynced: nodes.Informer().HasSynced,
+			listErrs:  listErrs,
+			ctx:       informerCtx,
+			stop:      stop,
+		}
+		factory.Start(informerCtx.Done())
+		go func() {
+			select {
+			case <-s.stopCh:
+				stop()
+			case <-informerCtx.Done():
+			}
+			factory.Shutdown()
+		}()
+		s.nodeInformers[name] = ni
+	}
+	s.nodeMu.Unlock()
+
+	// Waiters give up when another one stops the informer.
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(ni.ctx, cancel)()
+
+	synced, err := waitForSync(waitCtx, ni.hasSynced, ni.listErrs)
+	if err != nil || !synced {
+		s.stopNodeInformer(name, ni)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !synced {
+		return nil, errors.New("node informer failed to sync")
+	}
+	return ni, nil
+}
+
+// stopNodeInformer stops the informer ni of the named node and forgets
+// it, so that lookups of nodes that do not exist or cannot be listed do
+// not keep informers running. A later lookup starts a new one.
+func (s *MetadataRetrieverClientType) stopNodeInformer(name string, ni *nodeInformer) {
+	s.nodeMu.Lock()
+	if s.nodeInformers[name] == ni {
+		delete(s.nodeInformers, name)
+	}
+	s.nodeMu.Unlock()
+	ni.stop()
+}
+
+func (s *MetadataRetrieverClientType) isTopologyLabel(key string) bool {
+	switch {
+	case strings.HasPrefix(key, "topology.kubernetes.io/"),
+		strings.HasPrefix(key, "failure-domain.beta.kubernetes.io/"),
+		key == v1.LabelHostname:
+		return true
+	case s.driverName != "" && strings.HasPrefix(key, s.driverName+"/"):
+		return true
+	}
+	return false
+}
+
+func firstLabel(labels map[string]string, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := labels[k]; ok {
+			return v
+		}
+	}
+	return ""
+}
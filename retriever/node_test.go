@@ -0,0 +1,126 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetNodeMetadata(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker-1",
+			UID:  "uid-worker-1",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone":              "zone-a",
+				"failure-domain.beta.kubernetes.io/region": "region-1",
+				"kubernetes.io/hostname":                   "worker-1",
+				"csi.dell.com/10.0.0.1-iscsi":              "true",
+				"rack":                                     "r12",
+			},
+			Annotations: map[string]string{"owner": "infra"},
+		},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("16Gi"),
+			},
+		},
+	}
+	fakeClientset := fake.NewSimpleClientset(node)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.driverName = "csi.dell.com"
+	defer client.Close()
+
+	t.Setenv(EnvVarNodeName, "worker-1")
+	resp, err := client.GetNodeMetadata(context.Background(), &GetNodeMetadataRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "worker-1", resp.Name)
+	assert.Equal(t, "uid-worker-1", resp.UID)
+	assert.Equal(t, "r12", resp.Labels["rack"])
+	assert.Equal(t, map[string]string{"owner": "infra"}, resp.Annotations)
+	assert.Equal(t, "zone-a", resp.Zone)
+	assert.Equal(t, "region-1", resp.Region)
+	assert.Equal(t, map[string]string{
+		"topology.kubernetes.io/zone":              "zone-a",
+		"failure-domain.beta.kubernetes.io/region": "region-1",
+		"kubernetes.io/hostname":                   "worker-1",
+		"csi.dell.com/10.0.0.1-iscsi":              "true",
+	}, resp.TopologyLabels)
+	assert.Equal(t, map[string]string{"cpu": "4", "memory": "16Gi"}, resp.Allocatable)
+
+	// The second lookup reuses the informer started by the first.
+	_, err = client.GetNodeMetadata(context.Background(), &GetNodeMetadataRequest{Name: "worker-1"})
+	require.NoError(t, err)
+	assert.Len(t, client.nodeInformers, 1)
+}
+
+func TestGetNodeMetadata_Errors(t *testing.T) {
+	t.Setenv(EnvVarNodeName, "")
+	client := createTestClient(FakeGetClientset)
+	defer client.Close()
+
+	_, err := client.GetNodeMetadata(context.Background(), &GetNodeMetadataRequest{})
+	assert.EqualError(t, err, "Node Name cannot be empty")
+
+	_, err = client.GetNodeMetadata(context.Background(), &GetNodeMetadataRequest{Name: "missing"})
+	assert.Contains(t, err.Error(), "not found")
+	// The informer of a node that does not exist is stopped.
+	assert.Empty(t, client.nodeInformers)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.GetNodeMetadata(ctx, &GetNodeMetadataRequest{Name: "other"})
+	assert.EqualError(t, err, "node informer failed to sync")
+	assert.Empty(t, client.nodeInformers)
+
+	// A failing LIST is returned and its informer is stopped.
+	forbidden := fake.NewSimpleClientset()
+	forbidden.PrependReactor("list", "nodes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(v1.Resource("nodes"), "", errors.New("denied"))
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return forbidden, nil })
+	defer client.Close()
+	_, err = client.GetNodeMetadata(context.Background(), &GetNodeMetadataRequest{Name: "worker-1"})
+	assert.True(t, apierrors.IsForbidden(err), "%v", err)
+	assert.Empty(t, client.nodeInformers)
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetNodeMetadata(context.Background(), &GetNodeMetadataRequest{Name: "worker-1"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+}
+
+func TestClose(t *testing.T) {
+	client := NewMetadataRetrieverClient(nil, 0)
+	client.Close()
+	client.Close()
+	_, open := <-client.stopCh
+	assert.False(t, open)
+}
@@ -0,0 +1,108 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GetObjectMetadataRequest defines API request type. Group is empty for
+// the core API group.
+type GetObjectMetadataRequest struct {
+	Group     string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Version   string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Resource  string `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	NameSpace string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+// GetObjectMetadataResponse defines API response type
+type GetObjectMetadataResponse struct {
+	Name        string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace   string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID         string            `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations map[string]string `protobuf:"bytes,5,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// GetObjectMetadata gets the labels and annotations of any object whose
+// resource is in the allowlist set by X_CSI_RETRIEVER_OBJECT_ALLOWLIST.
+// Resources that are not allowed are refused with PermissionDenied, and
+// an empty allowlist refuses all of them.
+func (s *MetadataRetrieverClientType) GetObjectMetadata(
+	ctx context.Context,
+	req *GetObjectMetadataRequest) (
+	*GetObjectMetadataResponse, error,
+) {
+	gvr := schema.GroupVersionResource{Group: req.Group, Version: req.Version, Resource: req.Resource}
+	log.Infof("Get %s metadata for %s in namespace %s", gvr, req.Name, req.NameSpace)
+	if req.Name == "" {
+		return nil, errors.New("Object Name cannot be empty")
+	}
+	if _, ok := s.objectAllowlist[gvr]; !ok {
+		return nil, status.Errorf(codes.PermissionDenied,
+			"%s.%s/%s is not in the object allowlist", gvr.Resource, gvr.Group, gvr.Version)
+	}
+
+	obj, err := s.getDynamicObject(ctx, gvr, req.NameSpace, req.Name)
+	if err != nil {
+		log.Error("Error retrieving object info: ", err)
+		return nil, err
+	}
+
+	return &GetObjectMetadataResponse{
+		Name:        obj.GetName(),
+		NameSpace:   obj.GetNamespace(),
+		UID:         string(obj.GetUID()),
+		Labels:      s.redactMap(obj.GetLabels()),
+		Annotations: s.redactMap(obj.GetAnnotations()),
+	}, nil
+}
+
+// parseObjectAllowlist parses a comma separated list of group/version/resource
+// entries. Core resources are written as version/resource, e.g. v1/secrets.
+// Malformed entries are logged and skipped.
+func parseObjectAllowlist(value string) map[schema.GroupVersionResource]struct{} {
+	allowlist := map[schema.GroupVersionResource]struct{}{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		var gvr schema.GroupVersionResource
+		parts := strings.Split(entry, "/")
+		switch len(parts) {
+		case 2:
+			gvr = schema.GroupVersionResource{Version: parts[0], Resource: parts[1]}
+		case 3:
+			gvr = schema.GroupVersionResource{Group: parts[0], Version: parts[1], Resource: parts[2]}
+		}
+		if gvr.Version == "" || gvr.Resource == "" {
+			log.Warnf("Ignoring invalid %s entry %q", EnvVarObjectAllowlist, entry)
+			continue
+		}
+		allowlist[gvr] = struct{}{}
+	}
+	return allowlist
+}
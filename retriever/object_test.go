@@ -0,0 +1,100 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetObjectMetadata(t *testing.T) {
+	release := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":      "sh.helm.release.v1.db.v3",
+			"namespace": "apps",
+			"uid":       "uid-release",
+			"labels":    map[string]interface{}{"owner": "helm", "name": "db"},
+		},
+		"data": map[string]interface{}{"release": "c2VjcmV0"},
+	}}
+	application := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata": map[string]interface{}{
+			"name":        "db",
+			"namespace":   "argocd",
+			"uid":         "uid-application",
+			"annotations": map[string]interface{}{"team": "storage"},
+		},
+	}}
+	client := createTestDynamicClient(fake.NewSimpleClientset(), release, application)
+	client.objectAllowlist = parseObjectAllowlist("v1/secrets, argoproj.io/v1alpha1/applications")
+
+	resp, err := client.GetObjectMetadata(context.Background(), &GetObjectMetadataRequest{
+		Version: "v1", Resource: "secrets", NameSpace: "apps", Name: "sh.helm.release.v1.db.v3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &GetObjectMetadataResponse{
+		Name:        "sh.helm.release.v1.db.v3",
+		NameSpace:   "apps",
+		UID:         "uid-release",
+		Labels:      map[string]string{"owner": "helm", "name": "db"},
+		Annotations: map[string]string{},
+	}, resp)
+
+	resp, err = client.GetObjectMetadata(context.Background(), &GetObjectMetadataRequest{
+		Group: "argoproj.io", Version: "v1alpha1", Resource: "applications", NameSpace: "argocd", Name: "db",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "storage"}, resp.Annotations)
+
+	_, err = client.GetObjectMetadata(context.Background(), &GetObjectMetadataRequest{
+		Version: "v1", Resource: "configmaps", NameSpace: "apps", Name: "db",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.GetObjectMetadata(context.Background(), &GetObjectMetadataRequest{
+		Version: "v1", Resource: "secrets", NameSpace: "apps",
+	})
+	assert.EqualError(t, err, "Object Name cannot be empty")
+}
+
+func TestGetObjectMetadata_EmptyAllowlist(t *testing.T) {
+	client := createTestDynamicClient(fake.NewSimpleClientset())
+	_, err := client.GetObjectMetadata(context.Background(), &GetObjectMetadataRequest{
+		Version: "v1", Resource: "secrets", NameSpace: "apps", Name: "db",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestParseObjectAllowlist(t *testing.T) {
+	assert.Empty(t, parseObjectAllowlist(""))
+	assert.Equal(t, map[schema.GroupVersionResource]struct{}{
+		{Version: "v1", Resource: "secrets"}:                               {},
+		{Group: "tenancy.example.com", Version: "v1", Resource: "tenants"}: {},
+	}, parseObjectAllowlist("v1/secrets,,secrets,a/b/c/d,/v1/,tenancy.example.com/v1/tenants"))
+}
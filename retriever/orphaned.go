@@ -0,0 +1,173 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var timeNow = time.Now
+
+const (
+	// OrphanReasonReleased is reported for PVs in the Released phase
+	OrphanReasonReleased = "Released"
+
+	// OrphanReasonFailed is reported for PVs in the Failed phase
+	OrphanReasonFailed = "Failed"
+
+	// OrphanReasonClaimMissing is reported for PVs whose claimRef points
+	// at a PVC that no longer exists or has been recreated
+	OrphanReasonClaimMissing = "ClaimMissing"
+)
+
+// GetOrphanedVolumesRequest defines API request type
+type GetOrphanedVolumesRequest struct {
+	// DriverName selects PVs by spec.csi.driver. Defaults to the value of
+	// X_CSI_RETRIEVER_DRIVER_NAME.
+	DriverName string `protobuf:"bytes,1,opt,name=driver_name,proto3" json:"driver_name,omitempty"`
+}
+
+// OrphanedVolume describes a PV that is no longer in use by its claim
+type OrphanedVolume struct {
+	PVName         string `protobuf:"bytes,1,opt,name=pv_name,proto3" json:"pv_name,omitempty"`
+	VolumeHandle   string `protobuf:"bytes,2,opt,name=volume_handle,proto3" json:"volume_handle,omitempty"`
+	Phase          string `protobuf:"bytes,3,opt,name=phase,proto3" json:"phase,omitempty"`
+	ReclaimPolicy  string `protobuf:"bytes,4,opt,name=reclaim_policy,proto3" json:"reclaim_policy,omitempty"`
+	Reason         string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	ClaimName      string `protobuf:"bytes,6,opt,name=claim_name,proto3" json:"claim_name,omitempty"`
+	ClaimNameSpace string `protobuf:"bytes,7,opt,name=claim_namespace,proto3" json:"claim_namespace,omitempty"`
+	// CreationTimestamp is the PV creation time in RFC 3339 format.
+	CreationTimestamp string `protobuf:"bytes,8,opt,name=creation_timestamp,proto3" json:"creation_timestamp,omitempty"`
+	AgeSeconds        int64  `protobuf:"varint,9,opt,name=age_seconds,proto3" json:"age_seconds,omitempty"`
+}
+
+// GetOrphanedVolumesResponse defines API response type
+type GetOrphanedVolumesResponse struct {
+	Volumes []*OrphanedVolume `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+}
+
+// GetOrphanedVolumes reports the PVs of a CSI driver that are Released or
+// Failed, or whose claimRef points at a missing PVC.
+func (s *MetadataRetrieverClientType) GetOrphanedVolumes(
+	ctx context.Context,
+	req *GetOrphanedVolumesRequest) (
+	*GetOrphanedVolumesResponse, error,
+) {
+	driverName := req.DriverName
+	if driverName == "" {
+		driverName = s.driverName
+	}
+	log.Infof("Get orphaned volumes for driver %s", driverName)
+	if driverName == "" {
+		return nil, errors.New("driver name cannot be empty")
+	}
+	if err := s.checkDriverAccess(driverName); err != nil {
+		log.Error("Error checking driver access: ", err)
+		return nil, err
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	pvs, err := clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Error("Error listing PVs: ", err)
+		return nil, err
+	}
+
+	resp := &GetOrphanedVolumesResponse{
+		Volumes: []*OrphanedVolume{},
+	}
+	now := timeNow()
+	for i := range pvs.Items {
+		pv := &pvs.Items[i]
+		if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != driverName {
+			continue
+		}
+
+		var reason string
+		switch pv.Status.Phase {
+		case v1.VolumeReleased:
+			reason = OrphanReasonReleased
+		case v1.VolumeFailed:
+			reason = OrphanReasonFailed
+		default:
+			missing, err := s.claimMissing(ctx, clientset, pv)
+			if err != nil {
+				log.Error("Error retrieving PVC info: ", err)
+				return nil, err
+			}
+			if missing {
+				reason = OrphanReasonClaimMissing
+			}
+		}
+		if reason == "" {
+			continue
+		}
+
+		volume := &OrphanedVolume{
+			PVName:            pv.Name,
+			VolumeHandle:      pv.Spec.CSI.VolumeHandle,
+			Phase:             string(pv.Status.Phase),
+			ReclaimPolicy:     string(pv.Spec.PersistentVolumeReclaimPolicy),
+			Reason:            reason,
+			CreationTimestamp: pv.CreationTimestamp.UTC().Format(time.RFC3339),
+			AgeSeconds:        int64(now.Sub(pv.CreationTimestamp.Time).Seconds()),
+		}
+		if ref := pv.Spec.ClaimRef; ref != nil {
+			volume.ClaimName = ref.Name
+			volume.ClaimNameSpace = ref.Namespace
+		}
+		resp.Volumes = append(resp.Volumes, volume)
+	}
+
+	return resp, nil
+}
+
+// claimMissing reports whether the PVC referenced by a PV's claimRef no
+// longer exists, or exists with a different UID. A PV that is pre-bound to
+// a PVC which has not been created yet has a claimRef without a UID and is
+// not Bound; its claim is not missing.
+func (s *MetadataRetrieverClientType) claimMissing(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	pv *v1.PersistentVolume,
+) (bool, error) {
+	ref := pv.Spec.ClaimRef
+	if ref == nil || (ref.UID == "" && pv.Status.Phase != v1.VolumeBound) {
+		return false, nil
+	}
+	pvc, err := s.lookupPVC(ctx, clientset, ref.Namespace, ref.Name)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return ref.UID != "" && pvc.UID != ref.UID, nil
+}
@@ -0,0 +1,118 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetOrphanedVolumes(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return created.Add(time.Hour) }
+	defer func() { timeNow = time.Now }()
+
+	withPhase := func(pv *v1.PersistentVolume, phase v1.PersistentVolumePhase) *v1.PersistentVolume {
+		pv.Status.Phase = phase
+		pv.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimRetain
+		pv.CreationTimestamp = metav1.NewTime(created)
+		return pv
+	}
+	bound := newTestPVC("bound", "ns1", nil)
+	recreated := newTestPVC("recreated", "ns1", nil)
+	stale := newTestPV("pv-recreated", "csi.dell.com", "handle-4", recreated)
+	stale.Spec.ClaimRef.UID = "old-uid"
+	// Pre-bound to a PVC that has not been created yet.
+	preBound := newTestPV("pv-prebound", "csi.dell.com", "handle-8", newTestPVC("future", "ns1", nil))
+	preBound.Spec.ClaimRef.UID = ""
+
+	fakeClientset := fake.NewSimpleClientset(
+		bound, recreated,
+		withPhase(newTestPV("pv-bound", "csi.dell.com", "handle-1", bound), v1.VolumeBound),
+		withPhase(newTestPV("pv-released", "csi.dell.com", "handle-2", newTestPVC("old", "ns1", nil)), v1.VolumeReleased),
+		withPhase(newTestPV("pv-failed", "csi.dell.com", "handle-3", nil), v1.VolumeFailed),
+		withPhase(stale, v1.VolumeBound),
+		withPhase(newTestPV("pv-deleted", "csi.dell.com", "handle-5", newTestPVC("deleted", "ns2", nil)), v1.VolumeBound),
+		withPhase(newTestPV("pv-available", "csi.dell.com", "handle-6", nil), v1.VolumeAvailable),
+		withPhase(newTestPV("pv-other", "other.csi.io", "handle-7", nil), v1.VolumeReleased),
+		withPhase(preBound, v1.VolumeAvailable),
+	)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.driverName = "csi.dell.com"
+
+	resp, err := client.GetOrphanedVolumes(context.Background(), &GetOrphanedVolumesRequest{})
+	require.NoError(t, err)
+
+	reasons := map[string]string{}
+	for _, v := range resp.Volumes {
+		reasons[v.PVName] = v.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"pv-released":  OrphanReasonReleased,
+		"pv-failed":    OrphanReasonFailed,
+		"pv-recreated": OrphanReasonClaimMissing,
+		"pv-deleted":   OrphanReasonClaimMissing,
+	}, reasons)
+
+	for _, v := range resp.Volumes {
+		if v.PVName == "pv-deleted" {
+			assert.Equal(t, "handle-5", v.VolumeHandle)
+			assert.Equal(t, "Retain", v.ReclaimPolicy)
+			assert.Equal(t, "deleted", v.ClaimName)
+			assert.Equal(t, "ns2", v.ClaimNameSpace)
+			assert.Equal(t, "2026-01-01T00:00:00Z", v.CreationTimestamp)
+			assert.Equal(t, int64(3600), v.AgeSeconds)
+		}
+	}
+}
+
+func TestGetOrphanedVolumes_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.GetOrphanedVolumes(context.Background(), &GetOrphanedVolumesRequest{})
+	assert.EqualError(t, err, "driver name cannot be empty")
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetOrphanedVolumes(context.Background(), &GetOrphanedVolumesRequest{DriverName: "csi.dell.com"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+
+	fakeClientset := fake.NewSimpleClientset()
+	fakeClientset.PrependReactor("list", "persistentvolumes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("list failed")
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.GetOrphanedVolumes(context.Background(), &GetOrphanedVolumesRequest{DriverName: "csi.dell.com"})
+	assert.EqualError(t, err, "list failed")
+
+	fakeClientset = fake.NewSimpleClientset(newTestPV("pv1", "csi.dell.com", "handle-1", newTestPVC("pvc1", "ns1", nil)))
+	fakeClientset.PrependReactor("get", "persistentvolumeclaims", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("get failed")
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.GetOrphanedVolumes(context.Background(), &GetOrphanedVolumesRequest{DriverName: "csi.dell.com"})
+	assert.EqualError(t, err, "get failed")
+}
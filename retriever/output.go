@@ -0,0 +1,171 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// outputOptions shape the labels and annotations returned for a request
+type outputOptions struct {
+	redactor  *redactor
+	filter    *keyFilter
+	sanitizer *sanitizer
+
+	// requestFilter and requestSanitizer are set if the request chose the
+	// filter or the sanitizer, which a policy then does not replace.
+	requestFilter    bool
+	requestSanitizer bool
+
+	keyMappings    map[string]string
+	namespaceMerge string
+	namespace      *v1.Namespace
+}
+
+// outputFor returns the output options of a request. The request's own
+// filter and sanitization profile take precedence over the configured
+// defaults.
+func (s *MetadataRetrieverClientType) outputFor(reqFilter *KeyFilter, profile string) (*outputOptions, error) {
+	if s.configErr != nil {
+		return nil, s.configErr
+	}
+	opts := &outputOptions{redactor: s.config.redactor, filter: s.config.filter}
+	if reqFilter != nil {
+		f, err := compileKeyFilter(reqFilter)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts.filter = f
+		opts.requestFilter = true
+	}
+	opts.requestSanitizer = profile != ""
+	if profile == "" {
+		profile = s.config.DefaultSanitizationProfile
+	}
+	if profile != "" {
+		sanitizer, ok := s.config.sanitizers[profile]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown sanitization profile %q", profile)
+		}
+		opts.sanitizer = sanitizer
+	}
+	return opts, nil
+}
+
+// outputForPVC returns the output options for the metadata of pvc with
+// the first matching MetadataPolicy rule applied, together with that rule.
+// Requests that cover many PVCs pass lookups to share the reads of the
+// policy match between them; others pass nil.
+func (s *MetadataRetrieverClientType) outputForPVC(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	lookups *policyLookups,
+	opts *outputOptions,
+	pvc *v1.PersistentVolumeClaim,
+) (*outputOptions, *policyRule, error) {
+	rule, namespace, err := s.matchPolicyRule(ctx, clientset, lookups, pvc)
+	if err != nil {
+		return opts, nil, err
+	}
+	return opts.withRule(rule, namespace), rule, nil
+}
+
+// pvcMetadata returns the labels and annotations of pvc shaped by opts
+// with the first matching MetadataPolicy rule applied.
+func (s *MetadataRetrieverClientType) pvcMetadata(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	lookups *policyLookups,
+	opts *outputOptions,
+	pvc *v1.PersistentVolumeClaim,
+) (map[string]string, map[string]string, error) {
+	output, _, err := s.outputForPVC(ctx, clientset, lookups, opts, pvc)
+	if err != nil {
+		return nil, nil, err
+	}
+	labels, _ := output.apply("labels", pvc.Labels)
+	annotations, _ := output.apply("annotations", pvc.Annotations)
+	return labels, annotations, nil
+}
+
+// withRule returns the options with a MetadataPolicy rule applied, or the
+// options themselves if rule is nil.
+func (o *outputOptions) withRule(rule *policyRule, namespace *v1.Namespace) *outputOptions {
+	if rule == nil {
+		return o
+	}
+	withRule := *o
+	if rule.filter != nil && !o.requestFilter {
+		withRule.filter = rule.filter
+	}
+	if rule.sanitizer != nil && !o.requestSanitizer {
+		withRule.sanitizer = rule.sanitizer
+	}
+	withRule.keyMappings = rule.keyMappings
+	withRule.namespaceMerge = rule.namespaceMerge
+	withRule.namespace = namespace
+	return &withRule
+}
+
+// apply returns a redacted, merged, filtered, mapped and sanitized copy of
+// m, which holds the entries of field, and the changes the sanitization
+// made.
+func (o *outputOptions) apply(field string, m map[string]string) (map[string]string, []*SanitizationChange) {
+	out := o.redactor.apply(m)
+	if nsEntries := o.namespaceEntries(field); nsEntries != nil {
+		for k, v := range o.redactor.apply(nsEntries) {
+			if _, ok := out[k]; !ok || o.namespaceMerge == NamespaceMergeOverride {
+				out[k] = v
+			}
+		}
+	}
+	out = o.filter.apply(out)
+	if len(o.keyMappings) > 0 {
+		mapped := make(map[string]string, len(out))
+		for k, v := range out {
+			if _, renamed := o.keyMappings[k]; !renamed {
+				mapped[k] = v
+			}
+		}
+		// Renamed keys replace entries with the same key.
+		for from, to := range o.keyMappings {
+			if v, ok := out[from]; ok {
+				mapped[to] = v
+			}
+		}
+		out = mapped
+	}
+	return o.sanitizer.apply(field, out)
+}
+
+// namespaceEntries returns the namespace entries merged into field, or nil.
+func (o *outputOptions) namespaceEntries(field string) map[string]string {
+	if o.namespace == nil || (o.namespaceMerge != NamespaceMergeFill && o.namespaceMerge != NamespaceMergeOverride) {
+		return nil
+	}
+	switch field {
+	case "labels":
+		return o.namespace.Labels
+	case "annotations":
+		return o.namespace.Annotations
+	}
+	return nil
+}
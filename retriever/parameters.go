@@ -0,0 +1,202 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// CreateVolume parameter keys set by external-provisioner when it runs
+// with --extra-create-metadata.
+const (
+	ParameterPVCName      = "csi.storage.k8s.io/pvc/name"
+	ParameterPVCNamespace = "csi.storage.k8s.io/pvc/namespace"
+	ParameterPVName       = "csi.storage.k8s.io/pv/name"
+)
+
+// PVCIdentity identifies the PVC and PV a CreateVolume call is for
+type PVCIdentity struct {
+	PVCName      string
+	PVCNameSpace string
+	PVName       string
+}
+
+// PVCIdentityFromParameters extracts the PVC and PV names from the
+// parameters of a CSI CreateVolume request. It fails with an error naming
+// every missing key, which usually means external-provisioner is not
+// running with --extra-create-metadata.
+func PVCIdentityFromParameters(params map[string]string) (*PVCIdentity, error) {
+	id := &PVCIdentity{
+		PVCName:      params[ParameterPVCName],
+		PVCNameSpace: params[ParameterPVCNamespace],
+		PVName:       params[ParameterPVName],
+	}
+
+	var missing []string
+	if id.PVCName == "" {
+		missing = append(missing, ParameterPVCName)
+	}
+	if id.PVCNameSpace == "" {
+		missing = append(missing, ParameterPVCNamespace)
+	}
+	if id.PVName == "" {
+		missing = append(missing, ParameterPVName)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("CreateVolume parameters are missing %s; is external-provisioner running with --extra-create-metadata?",
+			strings.Join(missing, ", "))
+	}
+	return id, nil
+}
+
+// GetPVCMetadataFromParametersRequest defines API request type
+type GetPVCMetadataFromParametersRequest struct {
+	// Parameters are the parameters of the CSI CreateVolume request.
+	Parameters map[string]string `protobuf:"bytes,1,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Filter overrides the configured default key filter.
+	Filter *KeyFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// SanitizationProfile overrides the configured default profile.
+	SanitizationProfile string `protobuf:"bytes,3,opt,name=sanitization_profile,proto3" json:"sanitization_profile,omitempty"`
+	// Templates are the names of the configured templates to render. All
+	// of them are rendered if it is empty.
+	Templates []string `protobuf:"bytes,4,rep,name=templates,proto3" json:"templates,omitempty"`
+}
+
+// GetPVCMetadataResponse defines API response type
+type GetPVCMetadataResponse struct {
+	Name             string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace        string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID              string            `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	PVName           string            `protobuf:"bytes,4,opt,name=pv_name,proto3" json:"pv_name,omitempty"`
+	StorageClassName string            `protobuf:"bytes,5,opt,name=storage_class_name,proto3" json:"storage_class_name,omitempty"`
+	Labels           map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations      map[string]string `protobuf:"bytes,7,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Sanitization reports the changes made by the sanitization profile.
+	Sanitization []*SanitizationChange `protobuf:"bytes,8,rep,name=sanitization,proto3" json:"sanitization,omitempty"`
+	// Rendered holds the rendered templates by name.
+	Rendered map[string]string `protobuf:"bytes,9,rep,name=rendered,proto3" json:"rendered,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Stale is set if the API server was unreachable and the metadata was
+	// served from the persistent cache. Templates are not rendered then.
+	Stale bool `protobuf:"varint,10,opt,name=stale,proto3" json:"stale,omitempty"`
+}
+
+// GetPVCMetadataFromParameters gets the metadata of the PVC a CreateVolume
+// request is provisioning, as identified by its parameters.
+func (s *MetadataRetrieverClientType) GetPVCMetadataFromParameters(
+	ctx context.Context,
+	req *GetPVCMetadataFromParametersRequest) (
+	*GetPVCMetadataResponse, error,
+) {
+	id, err := PVCIdentityFromParameters(req.Parameters)
+	if err != nil {
+		log.Error("Error reading PVC identity: ", err)
+		return nil, err
+	}
+	log.Infof("Get PVC metadata for %s in namespace %s", id.PVCName, id.PVCNameSpace)
+
+	output, err := s.outputFor(req.Filter, req.SanitizationProfile)
+	if err != nil {
+		log.Error("Error reading output options: ", err)
+		return nil, err
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	var rule *policyRule
+	stale := false
+	pvc, err := s.lookupPVC(ctx, clientset, id.PVCNameSpace, id.PVCName)
+	if apierrors.IsNotFound(err) {
+		s.diskCache.remove(id.PVCNameSpace, id.PVCName)
+	}
+	if err != nil {
+		cached, cachedOutput, cachedRule, ok := s.fromDiskCache(ctx, id.PVCNameSpace, id.PVCName, output, err)
+		if !ok {
+			log.Error("Error retrieving PVC info: ", err)
+			s.recordPVCEvent(clientset, pvcReference(id.PVCNameSpace, id.PVCName), err)
+			return nil, err
+		}
+		pvc, output, rule, stale = cached, cachedOutput, cachedRule, true
+	} else {
+		if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+			log.Error("Error checking PVC access: ", err)
+			s.recordPVCEvent(clientset, pvc, err)
+			return nil, err
+		}
+
+		if output, rule, err = s.outputForPVC(ctx, clientset, nil, output, pvc); err != nil {
+			log.Error("Error matching MetadataPolicy: ", err)
+			s.recordPVCEvent(clientset, pvc, err)
+			return nil, err
+		}
+	}
+
+	templates, err := s.templatesFor(req.Templates, rule)
+	if err != nil {
+		log.Error("Error reading templates: ", err)
+		s.recordPVCEvent(clientset, pvc, err)
+		return nil, err
+	}
+	if stale && len(templates) > 0 {
+		// The template data is read from the API server.
+		log.Warn("API server unreachable, returning stale metadata without rendered templates")
+		templates = nil
+	}
+
+	resp := &GetPVCMetadataResponse{
+		Name:      pvc.Name,
+		NameSpace: pvc.Namespace,
+		UID:       string(pvc.UID),
+		PVName:    id.PVName,
+		Stale:     stale,
+	}
+	var labelChanges, annotationChanges []*SanitizationChange
+	resp.Labels, labelChanges = output.apply("labels", pvc.Labels)
+	resp.Annotations, annotationChanges = output.apply("annotations", pvc.Annotations)
+	resp.Sanitization = append(labelChanges, annotationChanges...)
+	if pvc.Spec.StorageClassName != nil {
+		resp.StorageClassName = *pvc.Spec.StorageClassName
+	}
+
+	if len(templates) > 0 {
+		data, err := s.templateData(ctx, clientset, pvc, id.PVName)
+		if err != nil {
+			log.Error("Error retrieving template data: ", err)
+			s.recordPVCEvent(clientset, pvc, err)
+			return nil, err
+		}
+		if resp.Rendered, err = renderTemplates(templates, data); err != nil {
+			log.Error("Error rendering templates: ", err)
+			s.recordPVCEvent(clientset, pvc, err)
+			return nil, err
+		}
+	}
+
+	if !stale {
+		s.recordPVCEvent(clientset, pvc, nil)
+		s.diskCache.store(pvc, output.namespace, rule)
+	}
+	return resp, nil
+}
@@ -0,0 +1,89 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testCreateVolumeParameters() map[string]string {
+	return map[string]string{
+		ParameterPVCName:      "pvc1",
+		ParameterPVCNamespace: "ns1",
+		ParameterPVName:       "pvc-1234",
+		"arrayID":             "array-1",
+	}
+}
+
+func TestPVCIdentityFromParameters(t *testing.T) {
+	id, err := PVCIdentityFromParameters(testCreateVolumeParameters())
+	require.NoError(t, err)
+	assert.Equal(t, &PVCIdentity{PVCName: "pvc1", PVCNameSpace: "ns1", PVName: "pvc-1234"}, id)
+
+	_, err = PVCIdentityFromParameters(map[string]string{ParameterPVCName: "pvc1"})
+	assert.EqualError(t, err, "CreateVolume parameters are missing csi.storage.k8s.io/pvc/namespace, csi.storage.k8s.io/pv/name; "+
+		"is external-provisioner running with --extra-create-metadata?")
+
+	_, err = PVCIdentityFromParameters(nil)
+	assert.Error(t, err)
+}
+
+func TestGetPVCMetadataFromParameters(t *testing.T) {
+	pvc := newTestPVC("pvc1", "ns1", map[string]string{"app": "db"})
+	pvc.Annotations = map[string]string{"owner": "team-a"}
+	storageClass := "powerstore"
+	pvc.Spec.StorageClassName = &storageClass
+	fakeClientset := fake.NewSimpleClientset(pvc)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	resp, err := client.GetPVCMetadataFromParameters(context.Background(), &GetPVCMetadataFromParametersRequest{
+		Parameters: testCreateVolumeParameters(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &GetPVCMetadataResponse{
+		Name:             "pvc1",
+		NameSpace:        "ns1",
+		UID:              "uid-pvc1",
+		PVName:           "pvc-1234",
+		StorageClassName: "powerstore",
+		Labels:           map[string]string{"app": "db"},
+		Annotations:      map[string]string{"owner": "team-a"},
+	}, resp)
+}
+
+func TestGetPVCMetadataFromParameters_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.GetPVCMetadataFromParameters(context.Background(), &GetPVCMetadataFromParametersRequest{})
+	assert.Error(t, err)
+
+	_, err = client.GetPVCMetadataFromParameters(context.Background(), &GetPVCMetadataFromParametersRequest{
+		Parameters: testCreateVolumeParameters(),
+	})
+	assert.Contains(t, err.Error(), "not found")
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetPVCMetadataFromParameters(context.Background(), &GetPVCMetadataFromParametersRequest{
+		Parameters: testCreateVolumeParameters(),
+	})
+	assert.EqualError(t, err, "simulated clientset creation error")
+}
@@ -0,0 +1,122 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Volume context keys set by kubelet for drivers with podInfoOnMount.
+const (
+	VolumeContextPodName            = "csi.storage.k8s.io/pod.name"
+	VolumeContextPodNamespace       = "csi.storage.k8s.io/pod.namespace"
+	VolumeContextPodUID             = "csi.storage.k8s.io/pod.uid"
+	VolumeContextServiceAccountName = "csi.storage.k8s.io/serviceAccount.name"
+)
+
+// GetPodMetadataRequest defines API request type. Name and NameSpace take
+// precedence over the pod keys found in VolumeContext.
+type GetPodMetadataRequest struct {
+	Name          string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace     string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	VolumeContext map[string]string `protobuf:"bytes,3,rep,name=volume_context,proto3" json:"volume_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// OwnerReference identifies an owner of an object
+type OwnerReference struct {
+	Kind       string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	UID        string `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Controller bool   `protobuf:"varint,4,opt,name=controller,proto3" json:"controller,omitempty"`
+}
+
+// GetPodMetadataResponse defines API response type
+type GetPodMetadataResponse struct {
+	Name               string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace          string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID                string            `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Labels             map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations        map[string]string `protobuf:"bytes,5,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ServiceAccountName string            `protobuf:"bytes,6,opt,name=service_account_name,proto3" json:"service_account_name,omitempty"`
+	NodeName           string            `protobuf:"bytes,7,opt,name=node_name,proto3" json:"node_name,omitempty"`
+	Owners             []*OwnerReference `protobuf:"bytes,8,rep,name=owners,proto3" json:"owners,omitempty"`
+}
+
+// GetPodMetadata gets the labels, annotations, service account and owners
+// of the pod a volume is published for. The pod may be given directly or
+// through the volume context that kubelet passes to NodePublishVolume.
+func (s *MetadataRetrieverClientType) GetPodMetadata(
+	ctx context.Context,
+	req *GetPodMetadataRequest) (
+	*GetPodMetadataResponse, error,
+) {
+	name, namespace := req.Name, req.NameSpace
+	if name == "" {
+		name = req.VolumeContext[VolumeContextPodName]
+	}
+	if namespace == "" {
+		namespace = req.VolumeContext[VolumeContextPodNamespace]
+	}
+	log.Infof("Get pod metadata for %s in namespace %s", name, namespace)
+	if name == "" {
+		return nil, errors.New("Pod Name cannot be empty")
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Error("Error retrieving pod info: ", err)
+		return nil, err
+	}
+
+	// A pod with the same name may have replaced the one the volume is
+	// being published for.
+	if uid := req.VolumeContext[VolumeContextPodUID]; uid != "" && uid != string(pod.UID) {
+		return nil, fmt.Errorf("pod %s/%s has UID %s, expected %s", namespace, name, pod.UID, uid)
+	}
+
+	resp := &GetPodMetadataResponse{
+		Name:               pod.Name,
+		NameSpace:          pod.Namespace,
+		UID:                string(pod.UID),
+		Labels:             s.redactMap(pod.Labels),
+		Annotations:        s.redactMap(pod.Annotations),
+		ServiceAccountName: pod.Spec.ServiceAccountName,
+		NodeName:           pod.Spec.NodeName,
+		Owners:             make([]*OwnerReference, 0, len(pod.OwnerReferences)),
+	}
+	for _, ref := range pod.OwnerReferences {
+		resp.Owners = append(resp.Owners, &OwnerReference{
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			UID:        string(ref.UID),
+			Controller: ref.Controller != nil && *ref.Controller,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,93 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPodMetadata(t *testing.T) {
+	controller := true
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-0",
+			Namespace:   "ns1",
+			UID:         "uid-web-0",
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{"audit": "yes"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "StatefulSet", Name: "web", UID: "uid-web", Controller: &controller},
+			},
+		},
+		Spec: v1.PodSpec{
+			ServiceAccountName: "web-sa",
+			NodeName:           "worker-1",
+		},
+	}
+	fakeClientset := fake.NewSimpleClientset(pod)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	resp, err := client.GetPodMetadata(context.Background(), &GetPodMetadataRequest{
+		VolumeContext: map[string]string{
+			VolumeContextPodName:            "web-0",
+			VolumeContextPodNamespace:       "ns1",
+			VolumeContextPodUID:             "uid-web-0",
+			VolumeContextServiceAccountName: "web-sa",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "uid-web-0", resp.UID)
+	assert.Equal(t, map[string]string{"app": "web"}, resp.Labels)
+	assert.Equal(t, map[string]string{"audit": "yes"}, resp.Annotations)
+	assert.Equal(t, "web-sa", resp.ServiceAccountName)
+	assert.Equal(t, "worker-1", resp.NodeName)
+	assert.Equal(t, []*OwnerReference{{Kind: "StatefulSet", Name: "web", UID: "uid-web", Controller: true}}, resp.Owners)
+
+	resp, err = client.GetPodMetadata(context.Background(), &GetPodMetadataRequest{Name: "web-0", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, "web-0", resp.Name)
+
+	_, err = client.GetPodMetadata(context.Background(), &GetPodMetadataRequest{
+		VolumeContext: map[string]string{
+			VolumeContextPodName:      "web-0",
+			VolumeContextPodNamespace: "ns1",
+			VolumeContextPodUID:       "uid-old",
+		},
+	})
+	assert.EqualError(t, err, "pod ns1/web-0 has UID uid-web-0, expected uid-old")
+}
+
+func TestGetPodMetadata_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.GetPodMetadata(context.Background(), &GetPodMetadataRequest{VolumeContext: map[string]string{}})
+	assert.EqualError(t, err, "Pod Name cannot be empty")
+
+	_, err = client.GetPodMetadata(context.Background(), &GetPodMetadataRequest{Name: "missing", NameSpace: "ns1"})
+	assert.Contains(t, err.Error(), "not found")
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetPodMetadata(context.Background(), &GetPodMetadataRequest{Name: "web-0"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+}
@@ -0,0 +1,518 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"text/template"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var metadataPolicyGVR = schema.GroupVersionResource{
+	Group:    "metadataretriever.storage.dell.com",
+	Version:  "v1alpha1",
+	Resource: "metadatapolicies",
+}
+
+// Namespace merge behaviors of a MetadataPolicyRule
+const (
+	// NamespaceMergeNone returns the PVC entries only.
+	NamespaceMergeNone = "None"
+	// NamespaceMergeFill adds the namespace entries the PVC does not set.
+	NamespaceMergeFill = "Fill"
+	// NamespaceMergeOverride adds the namespace entries, replacing the PVC
+	// entries with the same keys.
+	NamespaceMergeOverride = "Override"
+)
+
+// Status condition reported on every MetadataPolicy
+const (
+	PolicyConditionValid   = "Valid"
+	PolicyReasonValid      = "Valid"
+	PolicyReasonParseError = "ParseError"
+)
+
+// Annotations set on dynamically provisioned PVCs naming their provisioner
+const (
+	annStorageProvisioner     = "volume.kubernetes.io/storage-provisioner"
+	annBetaStorageProvisioner = "volume.beta.kubernetes.io/storage-provisioner"
+)
+
+// MetadataPolicySpec is the spec of a MetadataPolicy. Policies are
+// evaluated by descending Priority and then by name, and the first rule
+// that matches a PVC shapes the metadata returned for it.
+type MetadataPolicySpec struct {
+	Priority int32                `json:"priority,omitempty"`
+	Rules    []MetadataPolicyRule `json:"rules,omitempty"`
+}
+
+// MetadataPolicyScope selects the PVCs a rule applies to. Every field that
+// is set must match; an empty scope matches every PVC.
+type MetadataPolicyScope struct {
+	Provisioners      []string              `json:"provisioners,omitempty"`
+	StorageClasses    []string              `json:"storageClasses,omitempty"`
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// MetadataPolicyRule shapes the metadata returned for the PVCs in its
+// scope. Its filter and sanitization profile replace the configured
+// defaults, but not those set by a request, and its templates are added to
+// the configured ones. Redaction is not part of a policy and always
+// applies.
+type MetadataPolicyRule struct {
+	Scope  MetadataPolicyScope `json:"scope,omitempty"`
+	Filter *KeyFilter          `json:"filter,omitempty"`
+	// KeyMappings renames keys after filtering.
+	KeyMappings         map[string]string    `json:"keyMappings,omitempty"`
+	Templates           map[string]string    `json:"templates,omitempty"`
+	SanitizationProfile *SanitizationProfile `json:"sanitizationProfile,omitempty"`
+	// NamespaceMerge is None, the default, Fill or Override.
+	NamespaceMerge string `json:"namespaceMerge,omitempty"`
+}
+
+// policyRule is a compiled MetadataPolicyRule
+type policyRule struct {
+	policy            string
+	index             int
+	provisioners      map[string]struct{}
+	storageClasses    map[string]struct{}
+	namespaceSelector labels.Selector
+	filter            *keyFilter
+	keyMappings       map[string]string
+	templates         map[string]*template.Template
+	sanitizer         *sanitizer
+	namespaceMerge    string
+}
+
+// metadataPolicy is a compiled MetadataPolicy
+type metadataPolicy struct {
+	name     string
+	priority int32
+	rules    []*policyRule
+}
+
+// policyStore holds the valid MetadataPolicies
+type policyStore struct {
+	mu       sync.RWMutex
+	policies map[string]*metadataPolicy
+}
+
+// ordered returns the policies in evaluation order.
+func (ps *policyStore) ordered() []*metadataPolicy {
+	ps.mu.RLock()
+	policies := make([]*metadataPolicy, 0, len(ps.policies))
+	for _, p := range ps.policies {
+		policies = append(policies, p)
+	}
+	ps.mu.RUnlock()
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].priority != policies[j].priority {
+			return policies[i].priority > policies[j].priority
+		}
+		return policies[i].name < policies[j].name
+	})
+	return policies
+}
+
+func (ps *policyStore) set(name string, p *metadataPolicy) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if p == nil {
+		delete(ps.policies, name)
+		return
+	}
+	ps.policies[name] = p
+}
+
+// rule returns a rule of a policy, or nil if there is no such rule.
+func (ps *policyStore) rule(policy string, index int) *policyRule {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	p, ok := ps.policies[policy]
+	if !ok || index < 0 || index >= len(p.rules) {
+		return nil
+	}
+	return p.rules[index]
+}
+
+func toSet(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+// compileMetadataPolicy parses and validates a MetadataPolicy.
+func compileMetadataPolicy(obj *unstructured.Unstructured) (*metadataPolicy, error) {
+	spec := &MetadataPolicySpec{}
+	if raw, ok := obj.Object["spec"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(raw, spec, true); err != nil {
+			return nil, err
+		}
+	}
+
+	policy := &metadataPolicy{name: obj.GetName(), priority: spec.Priority}
+	for i := range spec.Rules {
+		rule := &spec.Rules[i]
+		compiled := &policyRule{
+			policy:         obj.GetName(),
+			index:          i,
+			provisioners:   toSet(rule.Scope.Provisioners),
+			storageClasses: toSet(rule.Scope.StorageClasses),
+			keyMappings:    rule.KeyMappings,
+			namespaceMerge: rule.NamespaceMerge,
+		}
+		var err error
+		if rule.Scope.NamespaceSelector != nil {
+			if compiled.namespaceSelector, err = metav1.LabelSelectorAsSelector(rule.Scope.NamespaceSelector); err != nil {
+				return nil, fmt.Errorf("rule %d: namespaceSelector: %v", i, err)
+			}
+		}
+		if compiled.filter, err = compileKeyFilter(rule.Filter); err != nil {
+			return nil, fmt.Errorf("rule %d: filter: %v", i, err)
+		}
+		if compiled.templates, err = compileTemplates(rule.Templates); err != nil {
+			return nil, fmt.Errorf("rule %d: templates: %v", i, err)
+		}
+		if compiled.sanitizer, err = compileSanitizationProfile(rule.SanitizationProfile); err != nil {
+			return nil, fmt.Errorf("rule %d: sanitizationProfile: %v", i, err)
+		}
+		switch rule.NamespaceMerge {
+		case "", NamespaceMergeNone, NamespaceMergeFill, NamespaceMergeOverride:
+		default:
+			return nil, fmt.Errorf("rule %d: unknown namespaceMerge %q", i, rule.NamespaceMerge)
+		}
+		policy.rules = append(policy.rules, compiled)
+	}
+	return policy, nil
+}
+
+// StartPolicyWatch watches the MetadataPolicy objects of the cluster and
+// applies the first matching rule to the PVC metadata returned, until ctx
+// is done. Parse errors are reported in the Valid condition of the policy,
+// which is then ignored. If the CRD is not installed an Unimplemented
+// error is returned.
+func (s *MetadataRetrieverClientType) StartPolicyWatch(ctx context.Context) error {
+	served, err := s.resourceServed(metadataPolicyGVR)
+	if err != nil {
+		log.Error("Error discovering MetadataPolicy resource: ", err)
+		return err
+	}
+	if !served {
+		return errResourceNotServed(metadataPolicyGVR)
+	}
+
+	dynamicClient, err := s.getDynamicClient()
+	if err != nil {
+		log.Error("Error creating dynamic client: ", err)
+		return err
+	}
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 0)
+	informer := factory.ForResource(metadataPolicyGVR).Informer()
+	update := func(obj interface{}) {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			s.updatePolicy(ctx, dynamicClient, u)
+		}
+	}
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    update,
+		UpdateFunc: func(_, newObj interface{}) { update(newObj) },
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if u, ok := obj.(*unstructured.Unstructured); ok {
+				s.policies.set(u.GetName(), nil)
+				log.Infof("MetadataPolicy %s removed", u.GetName())
+			}
+		},
+	})
+	if err != nil {
+		log.Error("Error registering MetadataPolicy event handler: ", err)
+		return err
+	}
+
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		factory.Shutdown()
+		return errors.New("MetadataPolicy informer failed to sync")
+	}
+	log.Info("MetadataPolicy watch started")
+
+	go func() {
+		<-ctx.Done()
+		factory.Shutdown()
+		s.policies.mu.Lock()
+		s.policies.policies = map[string]*metadataPolicy{}
+		s.policies.mu.Unlock()
+		log.Info("MetadataPolicy watch stopped")
+	}()
+	return nil
+}
+
+// updatePolicy compiles a MetadataPolicy and reports the outcome in its
+// Valid condition.
+func (s *MetadataRetrieverClientType) updatePolicy(ctx context.Context, dynamicClient dynamic.Interface, obj *unstructured.Unstructured) {
+	policy, err := compileMetadataPolicy(obj)
+	condition := metav1.Condition{
+		Type:               PolicyConditionValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             PolicyReasonValid,
+		Message:            "policy is valid",
+		ObservedGeneration: obj.GetGeneration(),
+	}
+	if err != nil {
+		log.Errorf("Invalid MetadataPolicy %s: %v", obj.GetName(), err)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = PolicyReasonParseError
+		condition.Message = err.Error()
+	} else {
+		log.Infof("MetadataPolicy %s applied", obj.GetName())
+	}
+	s.policies.set(obj.GetName(), policy)
+
+	if err := setPolicyCondition(ctx, dynamicClient, obj, condition); err != nil {
+		log.Errorf("Error updating status of MetadataPolicy %s: %v", obj.GetName(), err)
+	}
+}
+
+// setPolicyCondition writes condition to the status of the policy unless
+// it is already set, so that status updates do not trigger more updates.
+func setPolicyCondition(ctx context.Context, dynamicClient dynamic.Interface, obj *unstructured.Unstructured, condition metav1.Condition) error {
+	var conditions []metav1.Condition
+	if raw, ok, _ := unstructured.NestedSlice(obj.Object, "status", "conditions"); ok {
+		status := struct {
+			Conditions []metav1.Condition `json:"conditions"`
+		}{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(
+			map[string]interface{}{"conditions": raw}, &status); err == nil {
+			conditions = status.Conditions
+		}
+	}
+	if !meta.SetStatusCondition(&conditions, condition) {
+		return nil
+	}
+
+	raw := make([]interface{}, 0, len(conditions))
+	for i := range conditions {
+		c, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&conditions[i])
+		if err != nil {
+			return err
+		}
+		raw = append(raw, c)
+	}
+	updated := obj.DeepCopy()
+	if err := unstructured.SetNestedSlice(updated.Object, raw, "status", "conditions"); err != nil {
+		return err
+	}
+	_, err := dynamicClient.Resource(metadataPolicyGVR).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// policyLookups memoizes the StorageClass and Namespace reads of
+// matchPolicyRule for one request that covers many PVCs. A nil
+// *policyLookups reads through to the API server.
+type policyLookups struct {
+	mu             sync.Mutex
+	storageClasses map[string]*storagev1.StorageClass
+	namespaces     map[string]*v1.Namespace
+}
+
+func newPolicyLookups() *policyLookups {
+	return &policyLookups{
+		storageClasses: map[string]*storagev1.StorageClass{},
+		namespaces:     map[string]*v1.Namespace{},
+	}
+}
+
+// storageClass returns the named StorageClass, or nil if it does not
+// exist.
+func (l *policyLookups) storageClass(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	name string,
+) (*storagev1.StorageClass, error) {
+	if l != nil {
+		l.mu.Lock()
+		sc, ok := l.storageClasses[name]
+		l.mu.Unlock()
+		if ok {
+			return sc, nil
+		}
+	}
+	sc, err := clientset.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		sc, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if l != nil {
+		l.mu.Lock()
+		l.storageClasses[name] = sc
+		l.mu.Unlock()
+	}
+	return sc, nil
+}
+
+// namespace returns the named Namespace.
+func (l *policyLookups) namespace(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	name string,
+) (*v1.Namespace, error) {
+	if l != nil {
+		l.mu.Lock()
+		ns, ok := l.namespaces[name]
+		l.mu.Unlock()
+		if ok {
+			return ns, nil
+		}
+	}
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if l != nil {
+		l.mu.Lock()
+		l.namespaces[name] = ns
+		l.mu.Unlock()
+	}
+	return ns, nil
+}
+
+// matchPolicyRule returns the first policy rule that matches pvc, or nil.
+// The namespace of the PVC is returned as well if it had to be read.
+func (s *MetadataRetrieverClientType) matchPolicyRule(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	lookups *policyLookups,
+	pvc *v1.PersistentVolumeClaim,
+) (*policyRule, *v1.Namespace, error) {
+	policies := s.policies.ordered()
+	if len(policies) == 0 {
+		return nil, nil, nil
+	}
+
+	storageClass := ""
+	if pvc.Spec.StorageClassName != nil {
+		storageClass = *pvc.Spec.StorageClassName
+	}
+	var (
+		provisioner      string
+		provisionerKnown bool
+		namespace        *v1.Namespace
+	)
+	getProvisioner := func() (string, error) {
+		if provisionerKnown {
+			return provisioner, nil
+		}
+		provisionerKnown = true
+		var err error
+		provisioner, err = s.pvcProvisioner(ctx, clientset, lookups, pvc)
+		return provisioner, err
+	}
+	getNamespace := func() (*v1.Namespace, error) {
+		if namespace != nil {
+			return namespace, nil
+		}
+		var err error
+		namespace, err = lookups.namespace(ctx, clientset, pvc.Namespace)
+		return namespace, err
+	}
+
+	for _, policy := range policies {
+		for _, rule := range policy.rules {
+			if rule.storageClasses != nil {
+				if _, ok := rule.storageClasses[storageClass]; !ok {
+					continue
+				}
+			}
+			if rule.provisioners != nil {
+				p, err := getProvisioner()
+				if err != nil {
+					return nil, nil, err
+				}
+				if _, ok := rule.provisioners[p]; !ok {
+					continue
+				}
+			}
+			if rule.namespaceSelector != nil {
+				ns, err := getNamespace()
+				if err != nil {
+					return nil, nil, err
+				}
+				if !rule.namespaceSelector.Matches(labels.Set(ns.Labels)) {
+					continue
+				}
+			}
+			if rule.namespaceMerge == NamespaceMergeFill || rule.namespaceMerge == NamespaceMergeOverride {
+				if _, err := getNamespace(); err != nil {
+					return nil, nil, err
+				}
+			}
+			return rule, namespace, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// pvcProvisioner returns the provisioner of a PVC from its annotations or
+// its StorageClass, or "" if it has none.
+func (s *MetadataRetrieverClientType) pvcProvisioner(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	lookups *policyLookups,
+	pvc *v1.PersistentVolumeClaim,
+) (string, error) {
+	if p := pvc.Annotations[annStorageProvisioner]; p != "" {
+		return p, nil
+	}
+	if p := pvc.Annotations[annBetaStorageProvisioner]; p != "" {
+		return p, nil
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return "", nil
+	}
+	sc, err := lookups.storageClass(ctx, clientset, *pvc.Spec.StorageClassName)
+	if err != nil || sc == nil {
+		return "", err
+	}
+	return sc.Provisioner, nil
+}
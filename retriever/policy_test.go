@@ -0,0 +1,310 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestMetadataPolicy(name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "metadataretriever.storage.dell.com/v1alpha1",
+		"kind":       "MetadataPolicy",
+		"metadata":   map[string]interface{}{"name": name, "generation": int64(1)},
+		"spec":       spec,
+	}}
+}
+
+func setTestPolicy(t *testing.T, client *MetadataRetrieverClientType, name string, spec map[string]interface{}) {
+	t.Helper()
+	policy, err := compileMetadataPolicy(newTestMetadataPolicy(name, spec))
+	require.NoError(t, err)
+	client.policies.set(name, policy)
+}
+
+func TestCompileMetadataPolicy(t *testing.T) {
+	policy, err := compileMetadataPolicy(newTestMetadataPolicy("p", map[string]interface{}{
+		"priority": int64(5),
+		"rules": []interface{}{map[string]interface{}{
+			"scope": map[string]interface{}{
+				"provisioners":      []interface{}{"csi.example.com"},
+				"namespaceSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"tier": "prod"}},
+			},
+			"filter":              map[string]interface{}{"exclude": map[string]interface{}{"prefixes": []interface{}{"helm.sh/"}}},
+			"keyMappings":         map[string]interface{}{"app.kubernetes.io/name": "app"},
+			"templates":           map[string]interface{}{"name": "{{.PVCName}}"},
+			"sanitizationProfile": map[string]interface{}{"maxTags": int64(3)},
+			"namespaceMerge":      "Fill",
+		}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), policy.priority)
+	require.Len(t, policy.rules, 1)
+	assert.Equal(t, "tier=prod", policy.rules[0].namespaceSelector.String())
+
+	tests := map[string]map[string]interface{}{
+		"unknown field": {"rules": []interface{}{map[string]interface{}{"unknown": true}}},
+		"filter":        {"rules": []interface{}{map[string]interface{}{"filter": map[string]interface{}{"include": map[string]interface{}{"regexes": []interface{}{"("}}}}}},
+		"templates":     {"rules": []interface{}{map[string]interface{}{"templates": map[string]interface{}{"t": "{{"}}}},
+		"merge":         {"rules": []interface{}{map[string]interface{}{"namespaceMerge": "Sometimes"}}},
+		"selector": {"rules": []interface{}{map[string]interface{}{"scope": map[string]interface{}{
+			"namespaceSelector": map[string]interface{}{"matchExpressions": []interface{}{map[string]interface{}{"key": "a", "operator": "Bad"}}},
+		}}}},
+		"sanitization": {"rules": []interface{}{map[string]interface{}{"sanitizationProfile": map[string]interface{}{"maxTags": int64(-1)}}}},
+	}
+	for name, spec := range tests {
+		_, err := compileMetadataPolicy(newTestMetadataPolicy("p", spec))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestMetadataPolicy_Apply(t *testing.T) {
+	gold := newTestPVC("gold-pvc", "prod", map[string]string{
+		"app.kubernetes.io/name": "db",
+		"helm.sh/chart":          "db-1.0.0",
+		"team":                   "a",
+	})
+	gold.Spec.StorageClassName = stringPtr("gold")
+	other := newTestPVC("other-pvc", "dev", map[string]string{"team": "b", "helm.sh/chart": "x"})
+	other.Annotations = map[string]string{annStorageProvisioner: "csi.example.com"}
+
+	fakeClientset := fake.NewSimpleClientset(gold, other,
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod", "team": "platform"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "dev", "team": "platform"}}},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "gold"}, Provisioner: "csi.example.com"},
+	)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	// Without policies the labels are returned unchanged.
+	resp, err := client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "other-pvc", NameSpace: "dev"})
+	require.NoError(t, err)
+	assert.Equal(t, other.Labels, resp.Parameters)
+
+	setTestPolicy(t, client, "gold", map[string]interface{}{
+		"priority": int64(10),
+		"rules": []interface{}{map[string]interface{}{
+			"scope": map[string]interface{}{
+				"storageClasses":    []interface{}{"gold"},
+				"namespaceSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"env": "prod"}},
+			},
+			"filter":         map[string]interface{}{"exclude": map[string]interface{}{"prefixes": []interface{}{"helm.sh/"}}},
+			"keyMappings":    map[string]interface{}{"app.kubernetes.io/name": "app"},
+			"namespaceMerge": "Override",
+			"templates":      map[string]interface{}{"volumeName": "{{.Namespace}}-{{.PVCName}}"},
+		}},
+	})
+	setTestPolicy(t, client, "driver", map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{
+			"scope":          map[string]interface{}{"provisioners": []interface{}{"csi.example.com"}},
+			"filter":         map[string]interface{}{"include": map[string]interface{}{"keys": []interface{}{"team", "env"}}},
+			"namespaceMerge": "Fill",
+		}},
+	})
+
+	resp, err = client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "gold-pvc", NameSpace: "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "db", "team": "platform", "env": "prod"}, resp.Parameters)
+
+	// The gold rule does not match the dev namespace, the driver rule
+	// matches through the provisioner annotation.
+	resp, err = client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "other-pvc", NameSpace: "dev"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "b", "env": "dev"}, resp.Parameters)
+
+	// A request filter takes precedence over the policy filter.
+	resp, err = client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{
+		Name: "other-pvc", NameSpace: "dev", Filter: &KeyFilter{Include: &KeyMatch{Prefixes: []string{"helm.sh/"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"helm.sh/chart": "x"}, resp.Parameters)
+
+	batch, err := client.GetPVCLabelsBatch(context.Background(), &GetPVCLabelsBatchRequest{
+		Keys: []*PVCKey{{Name: "gold-pvc", NameSpace: "prod"}, {Name: "other-pvc", NameSpace: "dev"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "db", "team": "platform", "env": "prod"}, batch.Results[0].Parameters)
+	assert.Equal(t, map[string]string{"team": "b", "env": "dev"}, batch.Results[1].Parameters)
+
+	require.NoError(t, fakeClientset.Tracker().Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}}))
+	params := testCreateVolumeParameters()
+	params[ParameterPVCName], params[ParameterPVCNamespace] = "gold-pvc", "prod"
+	metadata, err := client.GetPVCMetadataFromParameters(context.Background(), &GetPVCMetadataFromParametersRequest{Parameters: params})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"volumeName": "prod-gold-pvc"}, metadata.Rendered)
+}
+
+func TestStartPolicyWatch(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset()
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	err := client.StartPolicyWatch(context.Background())
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	fakeClientset.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "metadataretriever.storage.dell.com/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "metadatapolicies", Kind: "MetadataPolicy"}},
+	}}
+	valid := newTestMetadataPolicy("valid", map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{"namespaceMerge": "Fill"}},
+	})
+	invalid := newTestMetadataPolicy("invalid", map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{"namespaceMerge": "Sometimes"}},
+	})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{metadataPolicyGVR: "MetadataPolicyList"}, valid, invalid)
+	client.getDynamicClient = func() (dynamic.Interface, error) { return dynamicClient, nil }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, client.StartPolicyWatch(ctx))
+
+	policies := client.policies.ordered()
+	require.Len(t, policies, 1)
+	assert.Equal(t, "valid", policies[0].name)
+
+	conditionOf := func(name string) map[string]interface{} {
+		obj, err := dynamicClient.Resource(metadataPolicyGVR).Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		if len(conditions) != 1 {
+			return nil
+		}
+		return conditions[0].(map[string]interface{})
+	}
+	assert.Eventually(t, func() bool { return conditionOf("invalid") != nil }, 5*time.Second, 10*time.Millisecond)
+	condition := conditionOf("invalid")
+	assert.Equal(t, "False", condition["status"])
+	assert.Equal(t, PolicyReasonParseError, condition["reason"])
+	assert.Contains(t, condition["message"], "unknown namespaceMerge")
+	assert.Equal(t, "True", conditionOf("valid")["status"])
+
+	require.NoError(t, dynamicClient.Resource(metadataPolicyGVR).Delete(ctx, "valid", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool { return len(client.policies.ordered()) == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+}
+
+func TestMetadataPolicy_ListGroupMembersAndWatch(t *testing.T) {
+	pvc := newTestPVC("db", "ns1", map[string]string{"app.kubernetes.io/name": "db", "helm.sh/chart": "db-1"})
+	fakeClientset := fake.NewSimpleClientset(pvc, newTestPV("pv-db", "csi.example.com", "vol-db", pvc))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	setTestPolicy(t, client, "all", map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{
+			"filter":      map[string]interface{}{"exclude": map[string]interface{}{"prefixes": []interface{}{"helm.sh/"}}},
+			"keyMappings": map[string]interface{}{"app.kubernetes.io/name": "app"},
+		}},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	list, err := client.ListVolumeMetadata(ctx, &ListVolumeMetadataRequest{DriverName: "csi.example.com"})
+	require.NoError(t, err)
+	require.Len(t, list.Volumes, 1)
+	assert.Equal(t, map[string]string{"app": "db"}, list.Volumes[0].PVCLabels)
+
+	group, err := client.GetVolumeGroupSnapshotMembers(ctx, &GetVolumeGroupSnapshotMembersRequest{
+		NameSpace: "ns1", LabelSelector: "app.kubernetes.io/name=db",
+	})
+	require.NoError(t, err)
+	require.Len(t, group.Members, 1)
+	assert.Equal(t, map[string]string{"app": "db"}, group.Members[0].Labels)
+
+	stream, err := client.WatchPVCMetadata(ctx, &WatchPVCMetadataRequest{NameSpace: "ns1"})
+	require.NoError(t, err)
+	ev := recvWithTimeout(t, stream)
+	assert.Equal(t, map[string]string{"app": "db"}, ev.NewLabels)
+
+	// A change of a filtered label is not reported.
+	pvcs := fakeClientset.CoreV1().PersistentVolumeClaims("ns1")
+	_, err = pvcs.Update(ctx, newTestPVC("db", "ns1", map[string]string{"app.kubernetes.io/name": "db", "helm.sh/chart": "db-2"}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = pvcs.Update(ctx, newTestPVC("db", "ns1", map[string]string{"app.kubernetes.io/name": "web"}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	ev = recvWithTimeout(t, stream)
+	assert.Equal(t, PVCMetadataModified, ev.Type)
+	assert.Equal(t, map[string]string{"app": "db"}, ev.OldLabels)
+	assert.Equal(t, map[string]string{"app": "web"}, ev.NewLabels)
+}
+
+func TestMetadataPolicy_ListMemoizesLookups(t *testing.T) {
+	objs := []runtime.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"env": "prod"}}},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast"}, Provisioner: "csi.example.com"},
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		pvc := newTestPVC(name, "ns1", map[string]string{"app.kubernetes.io/name": name})
+		pvc.Spec.StorageClassName = stringPtr("fast")
+		objs = append(objs, pvc, newTestPV("pv-"+name, "csi.example.com", "vol-"+name, pvc))
+	}
+	fakeClientset := fake.NewSimpleClientset(objs...)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	setTestPolicy(t, client, "prod", map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{
+			"scope": map[string]interface{}{
+				"provisioners":      []interface{}{"csi.example.com"},
+				"namespaceSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"env": "prod"}},
+			},
+			"keyMappings": map[string]interface{}{"app.kubernetes.io/name": "app"},
+		}},
+	})
+
+	countGets := func() map[string]int {
+		gets := map[string]int{}
+		for _, action := range fakeClientset.Actions() {
+			if action.GetVerb() == "get" {
+				gets[action.GetResource().Resource]++
+			}
+		}
+		return gets
+	}
+
+	list, err := client.ListVolumeMetadata(context.Background(), &ListVolumeMetadataRequest{DriverName: "csi.example.com"})
+	require.NoError(t, err)
+	require.Len(t, list.Volumes, 3)
+	for _, volume := range list.Volumes {
+		assert.Contains(t, volume.PVCLabels, "app")
+	}
+	gets := countGets()
+	assert.Equal(t, 1, gets["storageclasses"])
+	assert.Equal(t, 1, gets["namespaces"])
+
+	fakeClientset.ClearActions()
+	group, err := client.GetVolumeGroupSnapshotMembers(context.Background(), &GetVolumeGroupSnapshotMembersRequest{
+		NameSpace: "ns1", LabelSelector: "app.kubernetes.io/name",
+	})
+	require.NoError(t, err)
+	require.Len(t, group.Members, 3)
+	gets = countGets()
+	assert.Equal(t, 1, gets["storageclasses"])
+	assert.Equal(t, 1, gets["namespaces"])
+}
@@ -0,0 +1,141 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetPVCStatusRequest defines API request type
+type GetPVCStatusRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+// PVCCondition is a condition of a PVC
+type PVCCondition struct {
+	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason  string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// LastTransitionTime is in RFC 3339 format.
+	LastTransitionTime string `protobuf:"bytes,5,opt,name=last_transition_time,proto3" json:"last_transition_time,omitempty"`
+}
+
+// GetPVCStatusResponse defines API response type. Capacities are
+// Kubernetes quantities such as 10Gi and are empty when unset.
+type GetPVCStatusResponse struct {
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace  string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID        string `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Phase      string `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	VolumeName string `protobuf:"bytes,5,opt,name=volume_name,proto3" json:"volume_name,omitempty"`
+	// RequestedCapacity is spec.resources.requests.storage.
+	RequestedCapacity string `protobuf:"bytes,6,opt,name=requested_capacity,proto3" json:"requested_capacity,omitempty"`
+	// Capacity is the actual capacity in status.capacity.storage.
+	Capacity string `protobuf:"bytes,7,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// AllocatedCapacity is status.allocatedResources.storage, the size the
+	// resize controller is working towards.
+	AllocatedCapacity string `protobuf:"bytes,8,opt,name=allocated_capacity,proto3" json:"allocated_capacity,omitempty"`
+	// ResizeStatus is status.allocatedResourceStatuses.storage, e.g.
+	// ControllerResizeInProgress or NodeResizePending.
+	ResizeStatus string          `protobuf:"bytes,9,opt,name=resize_status,proto3" json:"resize_status,omitempty"`
+	Conditions   []*PVCCondition `protobuf:"bytes,10,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// ResizePending is true while the requested capacity exceeds the
+	// actual capacity.
+	ResizePending bool `protobuf:"varint,11,opt,name=resize_pending,proto3" json:"resize_pending,omitempty"`
+	// NodeExpansionPending is true when the controller has expanded the
+	// volume and the file system still has to be expanded on the node.
+	NodeExpansionPending bool `protobuf:"varint,12,opt,name=node_expansion_pending,proto3" json:"node_expansion_pending,omitempty"`
+}
+
+// GetPVCStatus gets the capacity, resize status and conditions of a PVC.
+// The PVC is read from the API server, not the informer cache, so that
+// expansion decisions see its latest status.
+func (s *MetadataRetrieverClientType) GetPVCStatus(
+	ctx context.Context,
+	req *GetPVCStatusRequest) (
+	*GetPVCStatusResponse, error,
+) {
+	log.Infof("Get PVC status for %s in namespace %s", req.Name, req.NameSpace)
+	if req.Name == "" {
+		return nil, errors.New("PVC Name cannot be empty")
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	pvc, err := clientset.CoreV1().PersistentVolumeClaims(req.NameSpace).Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Error("Error retrieving PVC info: ", err)
+		return nil, err
+	}
+	if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+		log.Error("Error checking PVC access: ", err)
+		return nil, err
+	}
+
+	resp := &GetPVCStatusResponse{
+		Name:         pvc.Name,
+		NameSpace:    pvc.Namespace,
+		UID:          string(pvc.UID),
+		Phase:        string(pvc.Status.Phase),
+		VolumeName:   pvc.Spec.VolumeName,
+		ResizeStatus: string(pvc.Status.AllocatedResourceStatuses[v1.ResourceStorage]),
+		Conditions:   make([]*PVCCondition, 0, len(pvc.Status.Conditions)),
+	}
+	requested, hasRequested := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	if hasRequested {
+		resp.RequestedCapacity = requested.String()
+	}
+	capacity, hasCapacity := pvc.Status.Capacity[v1.ResourceStorage]
+	if hasCapacity {
+		resp.Capacity = capacity.String()
+	}
+	if allocated, ok := pvc.Status.AllocatedResources[v1.ResourceStorage]; ok {
+		resp.AllocatedCapacity = allocated.String()
+	}
+	resp.ResizePending = hasRequested && hasCapacity && requested.Cmp(capacity) > 0
+	resp.NodeExpansionPending = resp.ResizeStatus == string(v1.PersistentVolumeClaimNodeResizePending)
+
+	for _, c := range pvc.Status.Conditions {
+		condition := &PVCCondition{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		}
+		if !c.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = c.LastTransitionTime.UTC().Format(time.RFC3339)
+		}
+		if c.Type == v1.PersistentVolumeClaimFileSystemResizePending && c.Status == v1.ConditionTrue {
+			resp.NodeExpansionPending = true
+		}
+		resp.Conditions = append(resp.Conditions, condition)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,123 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestResizingPVC(name, requested, capacity string) *v1.PersistentVolumeClaim {
+	pvc := newTestPVC(name, "default", nil)
+	pvc.Spec.VolumeName = "pv-" + name
+	pvc.Spec.Resources.Requests = v1.ResourceList{v1.ResourceStorage: resource.MustParse(requested)}
+	pvc.Status.Phase = v1.ClaimBound
+	pvc.Status.Capacity = v1.ResourceList{v1.ResourceStorage: resource.MustParse(capacity)}
+	return pvc
+}
+
+func TestGetPVCStatus(t *testing.T) {
+	transition := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	controllerResize := newTestResizingPVC("controller", "20Gi", "10Gi")
+	controllerResize.Status.AllocatedResources = v1.ResourceList{v1.ResourceStorage: resource.MustParse("20Gi")}
+	controllerResize.Status.AllocatedResourceStatuses = map[v1.ResourceName]v1.ClaimResourceStatus{
+		v1.ResourceStorage: v1.PersistentVolumeClaimControllerResizeInProgress,
+	}
+	controllerResize.Status.Conditions = []v1.PersistentVolumeClaimCondition{{
+		Type:               v1.PersistentVolumeClaimResizing,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(transition),
+	}}
+
+	nodeResize := newTestResizingPVC("node", "20Gi", "10Gi")
+	nodeResize.Status.Conditions = []v1.PersistentVolumeClaimCondition{{
+		Type:    v1.PersistentVolumeClaimFileSystemResizePending,
+		Status:  v1.ConditionTrue,
+		Message: "Waiting for user to (re-)start a pod to finish file system resize of volume on node.",
+	}}
+
+	nodeResizeStatus := newTestResizingPVC("node-status", "20Gi", "10Gi")
+	nodeResizeStatus.Status.AllocatedResourceStatuses = map[v1.ResourceName]v1.ClaimResourceStatus{
+		v1.ResourceStorage: v1.PersistentVolumeClaimNodeResizePending,
+	}
+
+	fakeClientset := fake.NewSimpleClientset(controllerResize, nodeResize, nodeResizeStatus,
+		newTestResizingPVC("done", "20Gi", "20Gi"), newTestPVC("pending", "default", nil))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	resp, err := client.GetPVCStatus(context.Background(), &GetPVCStatusRequest{Name: "controller", NameSpace: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, &GetPVCStatusResponse{
+		Name:              "controller",
+		NameSpace:         "default",
+		UID:               "uid-controller",
+		Phase:             "Bound",
+		VolumeName:        "pv-controller",
+		RequestedCapacity: "20Gi",
+		Capacity:          "10Gi",
+		AllocatedCapacity: "20Gi",
+		ResizeStatus:      "ControllerResizeInProgress",
+		Conditions: []*PVCCondition{{
+			Type:               "Resizing",
+			Status:             "True",
+			LastTransitionTime: "2026-05-01T12:00:00Z",
+		}},
+		ResizePending: true,
+	}, resp)
+
+	tests := []struct {
+		name                 string
+		resizePending        bool
+		nodeExpansionPending bool
+	}{
+		{"node", true, true},
+		{"node-status", true, true},
+		{"done", false, false},
+		{"pending", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.GetPVCStatus(context.Background(), &GetPVCStatusRequest{Name: tt.name, NameSpace: "default"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.resizePending, resp.ResizePending)
+			assert.Equal(t, tt.nodeExpansionPending, resp.NodeExpansionPending)
+		})
+	}
+}
+
+func TestGetPVCStatus_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.GetPVCStatus(context.Background(), &GetPVCStatusRequest{})
+	assert.EqualError(t, err, "PVC Name cannot be empty")
+
+	_, err = client.GetPVCStatus(context.Background(), &GetPVCStatusRequest{Name: "missing", NameSpace: "default"})
+	assert.Contains(t, err.Error(), "not found")
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetPVCStatus(context.Background(), &GetPVCStatusRequest{Name: "pvc", NameSpace: "default"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+}
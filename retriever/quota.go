@@ -0,0 +1,163 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// storageClassQuotaSuffix separates the StorageClass name from the resource
+// in per-class quota entries such as
+// gold.storageclass.storage.k8s.io/requests.storage.
+const storageClassQuotaSuffix = ".storageclass.storage.k8s.io/"
+
+// GetNamespaceStorageQuotaRequest defines API request type
+type GetNamespaceStorageQuotaRequest struct {
+	NameSpace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+// StorageQuota is a storage entry of a ResourceQuota. StorageClassName is
+// set for per-StorageClass entries, in which case Resource is the part of
+// the quota key after the class, e.g. requests.storage.
+type StorageQuota struct {
+	QuotaName        string `protobuf:"bytes,1,opt,name=quota_name,proto3" json:"quota_name,omitempty"`
+	StorageClassName string `protobuf:"bytes,2,opt,name=storage_class_name,proto3" json:"storage_class_name,omitempty"`
+	Resource         string `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	Hard             string `protobuf:"bytes,4,opt,name=hard,proto3" json:"hard,omitempty"`
+	Used             string `protobuf:"bytes,5,opt,name=used,proto3" json:"used,omitempty"`
+}
+
+// StorageLimit holds the PVC storage bounds of a LimitRange. Unset bounds
+// are empty.
+type StorageLimit struct {
+	LimitRangeName string `protobuf:"bytes,1,opt,name=limit_range_name,proto3" json:"limit_range_name,omitempty"`
+	Min            string `protobuf:"bytes,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max            string `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+// GetNamespaceStorageQuotaResponse defines API response type
+type GetNamespaceStorageQuotaResponse struct {
+	Quotas []*StorageQuota `protobuf:"bytes,1,rep,name=quotas,proto3" json:"quotas,omitempty"`
+	Limits []*StorageLimit `protobuf:"bytes,2,rep,name=limits,proto3" json:"limits,omitempty"`
+}
+
+// GetNamespaceStorageQuota gets the storage entries of the ResourceQuotas
+// of a namespace, including the per-StorageClass ones, together with the
+// PVC storage bounds of its LimitRanges.
+func (s *MetadataRetrieverClientType) GetNamespaceStorageQuota(
+	ctx context.Context,
+	req *GetNamespaceStorageQuotaRequest) (
+	*GetNamespaceStorageQuotaResponse, error,
+) {
+	log.Infof("Get storage quota for namespace %s", req.NameSpace)
+	if req.NameSpace == "" {
+		return nil, errors.New("Namespace cannot be empty")
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	quotas, err := clientset.CoreV1().ResourceQuotas(req.NameSpace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Error("Error listing ResourceQuotas: ", err)
+		return nil, err
+	}
+
+	limitRanges, err := clientset.CoreV1().LimitRanges(req.NameSpace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Error("Error listing LimitRanges: ", err)
+		return nil, err
+	}
+
+	resp := &GetNamespaceStorageQuotaResponse{
+		Quotas: []*StorageQuota{},
+		Limits: []*StorageLimit{},
+	}
+	for i := range quotas.Items {
+		resp.Quotas = append(resp.Quotas, storageQuotas(&quotas.Items[i])...)
+	}
+	for i := range limitRanges.Items {
+		lr := &limitRanges.Items[i]
+		for _, item := range lr.Spec.Limits {
+			if item.Type != v1.LimitTypePersistentVolumeClaim {
+				continue
+			}
+			limit := &StorageLimit{LimitRangeName: lr.Name}
+			if q, ok := item.Min[v1.ResourceStorage]; ok {
+				limit.Min = q.String()
+			}
+			if q, ok := item.Max[v1.ResourceStorage]; ok {
+				limit.Max = q.String()
+			}
+			if limit.Min != "" || limit.Max != "" {
+				resp.Limits = append(resp.Limits, limit)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// storageQuotas returns the storage entries of a ResourceQuota sorted by
+// resource name.
+func storageQuotas(quota *v1.ResourceQuota) []*StorageQuota {
+	names := make([]string, 0, len(quota.Spec.Hard))
+	for name := range quota.Spec.Hard {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var entries []*StorageQuota
+	for _, name := range names {
+		entry := &StorageQuota{QuotaName: quota.Name, Resource: name}
+		if class, resource, ok := strings.Cut(name, storageClassQuotaSuffix); ok {
+			entry.StorageClassName = class
+			entry.Resource = resource
+		} else if !isStorageQuotaResource(v1.ResourceName(name)) {
+			continue
+		}
+		hard := quota.Spec.Hard[v1.ResourceName(name)]
+		entry.Hard = hard.String()
+		if used, ok := quota.Status.Used[v1.ResourceName(name)]; ok {
+			entry.Used = used.String()
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func isStorageQuotaResource(name v1.ResourceName) bool {
+	switch name {
+	case v1.ResourceRequestsStorage,
+		v1.ResourcePersistentVolumeClaims,
+		v1.ResourceEphemeralStorage,
+		v1.ResourceRequestsEphemeralStorage,
+		v1.ResourceLimitsEphemeralStorage:
+		return true
+	}
+	return false
+}
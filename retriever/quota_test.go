@@ -0,0 +1,101 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetNamespaceStorageQuota(t *testing.T) {
+	quota := &v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage", Namespace: "default"},
+		Spec: v1.ResourceQuotaSpec{Hard: v1.ResourceList{
+			v1.ResourceRequestsStorage:                                resource.MustParse("100Gi"),
+			v1.ResourcePersistentVolumeClaims:                         resource.MustParse("10"),
+			"gold.storageclass.storage.k8s.io/requests.storage":       resource.MustParse("20Gi"),
+			"gold.storageclass.storage.k8s.io/persistentvolumeclaims": resource.MustParse("2"),
+			v1.ResourceRequestsCPU:                                    resource.MustParse("4"),
+		}},
+		Status: v1.ResourceQuotaStatus{Used: v1.ResourceList{
+			v1.ResourceRequestsStorage:                          resource.MustParse("30Gi"),
+			"gold.storageclass.storage.k8s.io/requests.storage": resource.MustParse("5Gi"),
+		}},
+	}
+	limitRange := &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-bounds", Namespace: "default"},
+		Spec: v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{
+			{
+				Type: v1.LimitTypeContainer,
+				Max:  v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+			},
+			{
+				Type: v1.LimitTypePersistentVolumeClaim,
+				Min:  v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+				Max:  v1.ResourceList{v1.ResourceStorage: resource.MustParse("50Gi")},
+			},
+		}},
+	}
+	fakeClientset := fake.NewSimpleClientset(quota, limitRange)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	resp, err := client.GetNamespaceStorageQuota(context.Background(), &GetNamespaceStorageQuotaRequest{NameSpace: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, []*StorageQuota{
+		{QuotaName: "storage", StorageClassName: "gold", Resource: "persistentvolumeclaims", Hard: "2"},
+		{QuotaName: "storage", StorageClassName: "gold", Resource: "requests.storage", Hard: "20Gi", Used: "5Gi"},
+		{QuotaName: "storage", Resource: "persistentvolumeclaims", Hard: "10"},
+		{QuotaName: "storage", Resource: "requests.storage", Hard: "100Gi", Used: "30Gi"},
+	}, resp.Quotas)
+	assert.Equal(t, []*StorageLimit{{LimitRangeName: "pvc-bounds", Min: "1Gi", Max: "50Gi"}}, resp.Limits)
+
+	resp, err = client.GetNamespaceStorageQuota(context.Background(), &GetNamespaceStorageQuotaRequest{NameSpace: "empty"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Quotas)
+	assert.Empty(t, resp.Limits)
+}
+
+func TestGetNamespaceStorageQuota_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.GetNamespaceStorageQuota(context.Background(), &GetNamespaceStorageQuotaRequest{})
+	assert.EqualError(t, err, "Namespace cannot be empty")
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetNamespaceStorageQuota(context.Background(), &GetNamespaceStorageQuotaRequest{NameSpace: "default"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+
+	for _, res := range []string{"resourcequotas", "limitranges"} {
+		fakeClientset := fake.NewSimpleClientset()
+		fakeClientset.PrependReactor("list", res, func(_ k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("list failed")
+		})
+		client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+		_, err = client.GetNamespaceStorageQuota(context.Background(), &GetNamespaceStorageQuotaRequest{NameSpace: "default"})
+		assert.EqualError(t, err, "list failed", res)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// RBAC self-check modes set by X_CSI_RETRIEVER_RBAC_CHECK.
+const (
+	// RBACCheckWarn logs the missing permissions and keeps serving with
+	// the affected features degraded. It is the default.
+	RBACCheckWarn = "warn"
+	// RBACCheckFail refuses to start when a permission is missing.
+	RBACCheckFail = "fail"
+	// RBACCheckOff skips the check.
+	RBACCheckOff = "off"
+)
+
+// Permission is a verb on a cluster-wide resource that a feature of the
+// retriever needs.
+type Permission struct {
+	Feature     string
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+}
+
+// RBACSelfCheck checks with SelfSubjectAccessReviews that the service
+// account holds the permissions of the enabled features. Missing
+// permissions are logged as a table and, in RBACCheckFail mode, returned
+// as an error. Outside a cluster the check is skipped with a warning.
+func RBACSelfCheck(ctx context.Context) error {
+	mode := rbacCheckMode()
+	if mode == RBACCheckOff {
+		return nil
+	}
+	if _, err := restInClusterConfig(); err != nil {
+		if errors.Is(err, rest.ErrNotInCluster) {
+			log.Warn("Not running in a cluster, skipping RBAC self-check")
+			return nil
+		}
+		return rbacCheckError(mode, err)
+	}
+
+	s := NewMetadataRetrieverClient(nil, 0)
+	defer s.Close()
+	return s.rbacSelfCheck(ctx, mode)
+}
+
+func (s *MetadataRetrieverClientType) rbacSelfCheck(ctx context.Context, mode string) error {
+	missing, err := s.MissingPermissions(ctx)
+	if err != nil {
+		log.Error("Error checking RBAC permissions: ", err)
+		return rbacCheckError(mode, err)
+	}
+	if len(missing) == 0 {
+		log.Info("RBAC self-check passed")
+		return nil
+	}
+
+	log.Warnf("RBAC self-check found %d missing permissions:\n%s", len(missing), permissionTable(missing))
+	features := map[string]struct{}{}
+	for _, p := range missing {
+		features[p.Feature] = struct{}{}
+	}
+	names := make([]string, 0, len(features))
+	for f := range features {
+		names = append(names, f)
+	}
+	sort.Strings(names)
+	return rbacCheckError(mode, fmt.Errorf("missing RBAC permissions for %s", strings.Join(names, ", ")))
+}
+
+// MissingPermissions returns the permissions of the enabled features that
+// the service account does not hold.
+func (s *MetadataRetrieverClientType) MissingPermissions(ctx context.Context) ([]Permission, error) {
+	clientset, err := s.getClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	perms, err := s.requiredPermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []Permission
+	for _, p := range perms {
+		review, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx,
+			&authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Verb:        p.Verb,
+						Group:       p.Group,
+						Resource:    p.Resource,
+						Subresource: p.Subresource,
+					},
+				},
+			}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if !review.Status.Allowed {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
+
+// requiredPermissions returns the permissions of the features enabled by
+// the environment. The snapshot, group snapshot and MetadataPolicy
+// features are only checked when discovery reports their CRDs as served.
+func (s *MetadataRetrieverClientType) requiredPermissions() ([]Permission, error) {
+	var perms []Permission
+	add := func(feature, group, resource string, verbs ...string) {
+		resource, subresource, _ := strings.Cut(resource, "/")
+		for _, verb := range verbs {
+			perms = append(perms, Permission{
+				Feature:     feature,
+				Verb:        verb,
+				Group:       group,
+				Resource:    resource,
+				Subresource: subresource,
+			})
+		}
+	}
+
+	add("pvc-metadata", "", "persistentvolumeclaims", "get", "list", "watch")
+	add("pvc-metadata", "", "persistentvolumes", "get", "list", "watch")
+	add("pvc-metadata", "", "namespaces", "get")
+	add("pvc-metadata", "storage.k8s.io", "storageclasses", "get")
+	add("pvc-metadata", "storage.k8s.io", "volumeattributesclasses", "get")
+	add("pod-metadata", "", "pods", "get")
+	add("storage-quota", "", "resourcequotas", "list")
+	add("storage-quota", "", "limitranges", "list")
+	if os.Getenv(EnvVarNodeName) != "" {
+		add("node-metadata", "", "nodes", "get", "list", "watch")
+	}
+	if s.emitEvents {
+		add("events", "", "events", "create", "patch")
+	}
+	if annotationPrefix() != "" {
+		add("annotations", "", "persistentvolumeclaims", "patch")
+		add("annotations", "", "persistentvolumes", "patch")
+	}
+	gvrs := make([]schema.GroupVersionResource, 0, len(s.objectAllowlist))
+	for gvr := range s.objectAllowlist {
+		gvrs = append(gvrs, gvr)
+	}
+	sort.Slice(gvrs, func(i, j int) bool { return gvrs[i].String() < gvrs[j].String() })
+	for _, gvr := range gvrs {
+		add("object-metadata", gvr.Group, gvr.Resource, "get")
+	}
+
+	for _, gvr := range []schema.GroupVersionResource{volumeSnapshotGVR, volumeSnapshotContentGVR} {
+		served, err := s.resourceServed(gvr)
+		if err != nil {
+			return nil, err
+		}
+		if served {
+			add("snapshot-metadata", gvr.Group, gvr.Resource, "get")
+		}
+	}
+	gvr, err := s.volumeGroupSnapshotGVR()
+	switch {
+	case err == nil:
+		add("group-snapshot-metadata", gvr.Group, gvr.Resource, "get")
+	case status.Code(err) != codes.Unimplemented:
+		return nil, err
+	}
+	served, err := s.resourceServed(metadataPolicyGVR)
+	if err != nil {
+		return nil, err
+	}
+	if served {
+		add("metadata-policy", metadataPolicyGVR.Group, metadataPolicyGVR.Resource, "get", "list", "watch")
+		add("metadata-policy", metadataPolicyGVR.Group, metadataPolicyGVR.Resource+"/status", "update")
+	}
+	return perms, nil
+}
+
+// permissionTable formats permissions as an aligned table.
+func permissionTable(perms []Permission) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FEATURE\tVERB\tGROUP\tRESOURCE")
+	for _, p := range perms {
+		group := p.Group
+		if group == "" {
+			group = "core"
+		}
+		resource := p.Resource
+		if p.Subresource != "" {
+			resource += "/" + p.Subresource
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Feature, p.Verb, group, resource)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// rbacCheckError returns err in RBACCheckFail mode and logs that the
+// retriever runs degraded otherwise.
+func rbacCheckError(mode string, err error) error {
+	if mode == RBACCheckFail {
+		return fmt.Errorf("RBAC self-check failed: %w", err)
+	}
+	log.Warnf("Continuing in degraded mode: %v", err)
+	return nil
+}
+
+// rbacCheckMode reads X_CSI_RETRIEVER_RBAC_CHECK.
+func rbacCheckMode() string {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv(EnvVarRBACCheck)))
+	switch mode {
+	case "":
+		return RBACCheckWarn
+	case RBACCheckWarn, RBACCheckFail, RBACCheckOff:
+		return mode
+	}
+	log.Warnf("Ignoring invalid %s value %q", EnvVarRBACCheck, mode)
+	return RBACCheckWarn
+}
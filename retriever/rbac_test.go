@@ -0,0 +1,166 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newRBACTestClientset returns a clientset whose SelfSubjectAccessReviews
+// deny the given "verb resource" pairs and allow everything else.
+func newRBACTestClientset(denied ...string) *fake.Clientset {
+	fakeClientset := fake.NewSimpleClientset()
+	fakeClientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = true
+		for _, d := range denied {
+			if d == attrs.Verb+" "+attrs.Resource {
+				review.Status.Allowed = false
+			}
+		}
+		return true, review, nil
+	})
+	return fakeClientset
+}
+
+func TestRequiredPermissions(t *testing.T) {
+	t.Setenv(EnvVarNodeName, "")
+	client := createTestClient(FakeGetClientset)
+	perms, err := client.requiredPermissions()
+	require.NoError(t, err)
+	assert.Contains(t, perms, Permission{Feature: "pvc-metadata", Verb: "get", Resource: "persistentvolumeclaims"})
+	assert.Contains(t, perms, Permission{Feature: "pvc-metadata", Verb: "get", Group: "storage.k8s.io", Resource: "storageclasses"})
+	assert.Contains(t, perms, Permission{Feature: "pvc-metadata", Verb: "get", Group: "storage.k8s.io", Resource: "volumeattributesclasses"})
+	assert.Contains(t, perms, Permission{Feature: "pod-metadata", Verb: "get", Resource: "pods"})
+	assert.Contains(t, perms, Permission{Feature: "storage-quota", Verb: "list", Resource: "resourcequotas"})
+	assert.Contains(t, perms, Permission{Feature: "storage-quota", Verb: "list", Resource: "limitranges"})
+	for _, p := range perms {
+		assert.Contains(t, []string{"pvc-metadata", "pod-metadata", "storage-quota"}, p.Feature)
+	}
+
+	t.Setenv(EnvVarNodeName, "node1")
+	t.Setenv(EnvVarEmitEvents, "true")
+	t.Setenv(EnvVarAnnotationPrefix, "array.dell.com/")
+	t.Setenv(EnvVarObjectAllowlist, "argoproj.io/v1alpha1/applications,v1/secrets")
+	fakeClientset := fake.NewSimpleClientset()
+	fakeClientset.Resources = []*metav1.APIResourceList{snapshotAPIResources, {
+		GroupVersion: "groupsnapshot.storage.k8s.io/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "volumegroupsnapshots", Namespaced: true}},
+	}, {
+		GroupVersion: "metadataretriever.storage.dell.com/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "metadatapolicies"}},
+	}}
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	perms, err = client.requiredPermissions()
+	require.NoError(t, err)
+	assert.Contains(t, perms, Permission{Feature: "node-metadata", Verb: "watch", Resource: "nodes"})
+	assert.Contains(t, perms, Permission{Feature: "events", Verb: "create", Resource: "events"})
+	assert.Contains(t, perms, Permission{Feature: "annotations", Verb: "patch", Resource: "persistentvolumes"})
+	assert.Contains(t, perms, Permission{Feature: "object-metadata", Verb: "get", Resource: "secrets"})
+	assert.Contains(t, perms, Permission{Feature: "object-metadata", Verb: "get", Group: "argoproj.io", Resource: "applications"})
+	policyGroup := "metadataretriever.storage.dell.com"
+	assert.Equal(t, []Permission{
+		{Feature: "snapshot-metadata", Verb: "get", Group: "snapshot.storage.k8s.io", Resource: "volumesnapshots"},
+		{Feature: "snapshot-metadata", Verb: "get", Group: "snapshot.storage.k8s.io", Resource: "volumesnapshotcontents"},
+		{Feature: "group-snapshot-metadata", Verb: "get", Group: "groupsnapshot.storage.k8s.io", Resource: "volumegroupsnapshots"},
+		{Feature: "metadata-policy", Verb: "get", Group: policyGroup, Resource: "metadatapolicies"},
+		{Feature: "metadata-policy", Verb: "list", Group: policyGroup, Resource: "metadatapolicies"},
+		{Feature: "metadata-policy", Verb: "watch", Group: policyGroup, Resource: "metadatapolicies"},
+		{Feature: "metadata-policy", Verb: "update", Group: policyGroup, Resource: "metadatapolicies", Subresource: "status"},
+	}, perms[len(perms)-7:])
+	assert.Contains(t, permissionTable(perms[len(perms)-1:]), "metadatapolicies/status")
+
+	fakeClientset.PrependReactor("get", "resource", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("discovery failed")
+	})
+	_, err = client.requiredPermissions()
+	assert.EqualError(t, err, "discovery failed")
+}
+
+func TestMissingPermissions(t *testing.T) {
+	fakeClientset := newRBACTestClientset("watch persistentvolumes", "get namespaces")
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	missing, err := client.MissingPermissions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Permission{
+		{Feature: "pvc-metadata", Verb: "watch", Resource: "persistentvolumes"},
+		{Feature: "pvc-metadata", Verb: "get", Resource: "namespaces"},
+	}, missing)
+
+	assert.Equal(t, "FEATURE       VERB   GROUP  RESOURCE\n"+
+		"pvc-metadata  watch  core   persistentvolumes\n"+
+		"pvc-metadata  get    core   namespaces\n", permissionTable(missing))
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.MissingPermissions(context.Background())
+	assert.EqualError(t, err, "simulated clientset creation error")
+}
+
+func TestRBACSelfCheck(t *testing.T) {
+	ctx := context.Background()
+	denied := newRBACTestClientset("get persistentvolumeclaims")
+	client := createTestClient(func() (kubernetes.Interface, error) { return denied, nil })
+	assert.NoError(t, client.rbacSelfCheck(ctx, RBACCheckWarn))
+	assert.EqualError(t, client.rbacSelfCheck(ctx, RBACCheckFail),
+		"RBAC self-check failed: missing RBAC permissions for pvc-metadata")
+
+	allowed := newRBACTestClientset()
+	client = createTestClient(func() (kubernetes.Interface, error) { return allowed, nil })
+	assert.NoError(t, client.rbacSelfCheck(ctx, RBACCheckFail))
+
+	failing := fake.NewSimpleClientset()
+	failing.PrependReactor("create", "selfsubjectaccessreviews", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("review failed")
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return failing, nil })
+	assert.NoError(t, client.rbacSelfCheck(ctx, RBACCheckWarn))
+	assert.EqualError(t, client.rbacSelfCheck(ctx, RBACCheckFail), "RBAC self-check failed: review failed")
+}
+
+func TestRBACSelfCheck_Mode(t *testing.T) {
+	defer func() {
+		restInClusterConfig = rest.InClusterConfig
+	}()
+	restInClusterConfig = func() (*rest.Config, error) { return nil, rest.ErrNotInCluster }
+	t.Setenv(EnvVarRBACCheck, "fail")
+	assert.NoError(t, RBACSelfCheck(context.Background()))
+
+	restInClusterConfig = mockInClusterConfigError
+	assert.EqualError(t, RBACSelfCheck(context.Background()), "RBAC self-check failed: mock error")
+
+	t.Setenv(EnvVarRBACCheck, "OFF")
+	assert.NoError(t, RBACSelfCheck(context.Background()))
+
+	t.Setenv(EnvVarRBACCheck, "bogus")
+	assert.Equal(t, RBACCheckWarn, rbacCheckMode())
+	t.Setenv(EnvVarRBACCheck, "")
+	assert.Equal(t, RBACCheckWarn, rbacCheckMode())
+}
@@ -0,0 +1,217 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Redaction actions
+const (
+	RedactionActionDrop = "Drop"
+	RedactionActionMask = "Mask"
+)
+
+const defaultRedactionMask = "REDACTED"
+
+// RedactionRule selects sensitive label and annotation entries by key or
+// by value. An entry is redacted if its key matches Keys or its value
+// matches one of ValueRegexes.
+type RedactionRule struct {
+	Keys         *KeyMatch `json:"keys,omitempty"`
+	ValueRegexes []string  `json:"valueRegexes,omitempty"`
+	// Action is Drop, the default, or Mask. An entry matched by both kinds
+	// of rules is dropped.
+	Action string `json:"action,omitempty"`
+}
+
+// Redaction configures the redaction of labels and annotations in every
+// response and of matching values in log messages.
+type Redaction struct {
+	Rules []*RedactionRule `json:"rules,omitempty"`
+	// Mask replaces the values of masked entries. It defaults to REDACTED.
+	Mask string `json:"mask,omitempty"`
+}
+
+// redactionRule is a compiled RedactionRule
+type redactionRule struct {
+	keys   *keyMatcher
+	values []*regexp.Regexp
+	drop   bool
+}
+
+// redactor is a compiled Redaction. A nil redactor redacts nothing.
+type redactor struct {
+	rules []*redactionRule
+	mask  string
+}
+
+func compileRedaction(r *Redaction) (*redactor, error) {
+	if r == nil || len(r.Rules) == 0 {
+		return nil, nil
+	}
+	red := &redactor{mask: r.Mask}
+	if red.mask == "" {
+		red.mask = defaultRedactionMask
+	}
+	for i, rule := range r.Rules {
+		if rule == nil {
+			continue
+		}
+		compiled := &redactionRule{}
+		switch rule.Action {
+		case "", RedactionActionDrop:
+			compiled.drop = true
+		case RedactionActionMask:
+		default:
+			return nil, fmt.Errorf("rule %d: unknown action %q", i, rule.Action)
+		}
+		var err error
+		if compiled.keys, err = compileKeyMatch(rule.Keys); err != nil {
+			return nil, fmt.Errorf("rule %d: %v", i, err)
+		}
+		for _, expr := range rule.ValueRegexes {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: invalid value regex %q: %v", i, expr, err)
+			}
+			compiled.values = append(compiled.values, re)
+		}
+		red.rules = append(red.rules, compiled)
+	}
+	return red, nil
+}
+
+// matches reports whether the entry is redacted and whether it is dropped.
+func (r *redactor) matches(key, value string) (redacted, drop bool) {
+	for _, rule := range r.rules {
+		matched := false
+		if rule.keys != nil {
+			matched, _ = rule.keys.match(key)
+		}
+		for _, re := range rule.values {
+			if matched {
+				break
+			}
+			matched = re.MatchString(value)
+		}
+		if matched {
+			redacted = true
+			drop = drop || rule.drop
+		}
+	}
+	return redacted, drop
+}
+
+// apply returns a copy of m with the redacted entries dropped or masked.
+func (r *redactor) apply(m map[string]string) map[string]string {
+	if r == nil {
+		return copyMap(m)
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		redacted, drop := r.matches(k, v)
+		switch {
+		case drop:
+		case redacted:
+			out[k] = r.mask
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// masksValues reports whether any rule matches values, which are then
+// also masked in log entries.
+func (r *redactor) masksValues() bool {
+	if r == nil {
+		return false
+	}
+	for _, rule := range r.rules {
+		if len(rule.values) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// redactString masks every part of s matched by a value regex.
+func (r *redactor) redactString(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, rule := range r.rules {
+		for _, re := range rule.values {
+			s = re.ReplaceAllLiteralString(s, r.mask)
+		}
+	}
+	return s
+}
+
+// redactMap returns a copy of m, a set of labels or annotations, with the
+// configured redaction applied. If the configuration could not be loaded
+// nothing is returned, as it is unknown what must be redacted.
+func (s *MetadataRetrieverClientType) redactMap(m map[string]string) map[string]string {
+	if s.configErr != nil {
+		return map[string]string{}
+	}
+	return s.config.redactor.apply(m)
+}
+
+// RedactMap applies the configured redaction to a set of labels or
+// annotations, so that callers can log or audit them consistently with
+// the responses of the retriever.
+func (s *MetadataRetrieverClientType) RedactMap(m map[string]string) map[string]string {
+	return s.redactMap(m)
+}
+
+// logHookOnce adds the redaction hook of the first client that masks
+// values to the standard logger, so that repeated construction does not
+// stack hooks on it.
+var logHookOnce sync.Once
+
+// redactionHook is a logrus hook that masks redacted values in log
+// messages and string fields.
+type redactionHook struct {
+	redactor *redactor
+}
+
+// LogHook returns a logrus hook that masks the values matched by the
+// configured redaction rules in log entries. The standard logger gets the
+// hook of the first client created with value masking enabled; callers
+// with their own loggers can add it to them.
+func (s *MetadataRetrieverClientType) LogHook() log.Hook {
+	return &redactionHook{redactor: s.config.redactor}
+}
+
+func (h *redactionHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *redactionHook) Fire(entry *log.Entry) error {
+	entry.Message = h.redactor.redactString(entry.Message)
+	for k, v := range entry.Data {
+		if str, ok := v.(string); ok {
+			entry.Data[k] = h.redactor.redactString(str)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testRedactionConfig(t *testing.T) *Config {
+	t.Helper()
+	config := &Config{Redaction: &Redaction{Rules: []*RedactionRule{
+		{Keys: &KeyMatch{Keys: []string{"kubectl.kubernetes.io/last-applied-configuration"}}},
+		{ValueRegexes: []string{`[\w.]+@example\.com`}, Action: RedactionActionMask},
+		{Keys: &KeyMatch{Prefixes: []string{"secret."}}, Action: RedactionActionMask},
+		{Keys: &KeyMatch{Keys: []string{"secret.token"}}},
+	}}}
+	require.NoError(t, config.compile())
+	return config
+}
+
+func TestRedactorApply(t *testing.T) {
+	config := testRedactionConfig(t)
+	assert.Equal(t, map[string]string{
+		"owner":         "REDACTED",
+		"secret.ticket": "REDACTED",
+		"app":           "db",
+	}, config.redactor.apply(map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": "{...}",
+		"owner":         "jane.doe@example.com",
+		"secret.ticket": "1234",
+		"secret.token":  "abcd",
+		"app":           "db",
+	}))
+
+	var nilRedactor *redactor
+	assert.Equal(t, map[string]string{"k": "v"}, nilRedactor.apply(map[string]string{"k": "v"}))
+	assert.Equal(t, "x", nilRedactor.redactString("x"))
+	assert.False(t, nilRedactor.masksValues())
+}
+
+func TestCompileRedaction(t *testing.T) {
+	_, err := compileRedaction(&Redaction{Rules: []*RedactionRule{{Action: "Hide"}}})
+	assert.ErrorContains(t, err, "unknown action")
+	_, err = compileRedaction(&Redaction{Rules: []*RedactionRule{{ValueRegexes: []string{"("}}}})
+	assert.ErrorContains(t, err, "invalid value regex")
+	_, err = compileRedaction(&Redaction{Rules: []*RedactionRule{{Keys: &KeyMatch{Regexes: []string{"("}}}}})
+	assert.ErrorContains(t, err, "invalid key regex")
+
+	r, err := compileRedaction(&Redaction{Rules: []*RedactionRule{{ValueRegexes: []string{"x"}}}, Mask: "***"})
+	require.NoError(t, err)
+	assert.Equal(t, "a***b", r.redactString("axb"))
+}
+
+func TestRedaction_Responses(t *testing.T) {
+	pvc := newTestPVC("pvc1", "ns1", map[string]string{"owner": "jane@example.com", "app": "db"})
+	pvc.Annotations = map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{...}"}
+	fakeClientset := fake.NewSimpleClientset(pvc)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.config = testRedactionConfig(t)
+
+	labels, err := client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "REDACTED", "app": "db"}, labels.Parameters)
+
+	// A request filter cannot bring redacted entries back.
+	labels, err = client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{
+		Name: "pvc1", NameSpace: "ns1", Filter: &KeyFilter{Include: &KeyMatch{Keys: []string{"owner"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "REDACTED"}, labels.Parameters)
+
+	metadata, err := client.GetPVCMetadataFromParameters(context.Background(),
+		&GetPVCMetadataFromParametersRequest{Parameters: testCreateVolumeParameters()})
+	require.NoError(t, err)
+	assert.Empty(t, metadata.Annotations)
+
+	assert.Equal(t, map[string]string{"owner": "REDACTED"}, client.RedactMap(map[string]string{"owner": "a@example.com"}))
+
+	// Nothing is returned if the configuration could not be loaded.
+	client.configErr = errors.New("bad config")
+	assert.Empty(t, client.RedactMap(map[string]string{"app": "db"}))
+}
+
+func TestRedaction_LogHook(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	client.config = testRedactionConfig(t)
+
+	var buf bytes.Buffer
+	logger := log.New()
+	logger.SetOutput(&buf)
+	logger.AddHook(client.LogHook())
+	logger.WithField("owner", "jane@example.com").Info("PVC owned by john@example.com")
+
+	assert.NotContains(t, buf.String(), "example.com")
+	assert.Contains(t, buf.String(), "PVC owned by REDACTED")
+	assert.Contains(t, buf.String(), "owner=REDACTED")
+}
+
+func TestNewMetadataRetrieverClient_LogHookOnce(t *testing.T) {
+	t.Setenv(EnvVarConfigFile, writeTestConfig(t, `
+redaction:
+  rules:
+  - valueRegexes: ["[\\w.]+@example\\.com"]
+    action: Mask
+`))
+	countHooks := func() int {
+		n := 0
+		for _, h := range log.StandardLogger().Hooks[log.InfoLevel] {
+			if _, ok := h.(*redactionHook); ok {
+				n++
+			}
+		}
+		return n
+	}
+
+	for i := 0; i < 3; i++ {
+		client := NewMetadataRetrieverClient(nil, 0)
+		require.NoError(t, client.configErr)
+		require.True(t, client.config.redactor.masksValues())
+		client.Close()
+	}
+	assert.Equal(t, 1, countHooks())
+}
@@ -0,0 +1,102 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ownsPVC reports whether the PVC belongs to driverName. A bound PVC is
+// decided by the CSI driver of its PV alone, as PVC owners can set the
+// provisioner annotation; an unbound PVC by its provisioner.
+func (s *MetadataRetrieverClientType) ownsPVC(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	pvc *v1.PersistentVolumeClaim,
+	driverName string,
+) (bool, error) {
+	if pvc.Spec.VolumeName == "" {
+		provisioner, err := s.pvcProvisioner(ctx, clientset, nil, pvc)
+		if err != nil {
+			return false, err
+		}
+		return provisioner == driverName, nil
+	}
+	pv, err := clientset.CoreV1().PersistentVolumes().Get(ctx, pvc.Spec.VolumeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return pv.Spec.CSI != nil && pv.Spec.CSI.Driver == driverName, nil
+}
+
+// checkPVCAccess returns a PermissionDenied error if the client only
+// answers for the PVCs of its driver and pvc is not one of them.
+func (s *MetadataRetrieverClientType) checkPVCAccess(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	pvc *v1.PersistentVolumeClaim,
+) error {
+	if !s.restrictToDriver {
+		return nil
+	}
+	if s.driverName == "" {
+		return status.Errorf(codes.PermissionDenied,
+			"%s is set but %s is not", EnvVarRestrictToDriver, EnvVarDriverName)
+	}
+	owned, err := s.ownsPVC(ctx, clientset, pvc, s.driverName)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return status.Errorf(codes.PermissionDenied,
+			"PVC %s/%s is not provisioned by driver %s", pvc.Namespace, pvc.Name, s.driverName)
+	}
+	return nil
+}
+
+// checkPVAccess returns a PermissionDenied error if the client only
+// answers for the volumes of its driver and pv is not one of them.
+func (s *MetadataRetrieverClientType) checkPVAccess(pv *v1.PersistentVolume) error {
+	if !s.restrictToDriver {
+		return nil
+	}
+	if pv.Spec.CSI == nil || pv.Spec.CSI.Driver != s.driverName || s.driverName == "" {
+		return status.Errorf(codes.PermissionDenied,
+			"PV %s is not provisioned by driver %s", pv.Name, s.driverName)
+	}
+	return nil
+}
+
+// checkDriverAccess returns a PermissionDenied error if the client only
+// answers for the volumes of its driver and driverName is another one.
+func (s *MetadataRetrieverClientType) checkDriverAccess(driverName string) error {
+	if s.restrictToDriver && driverName != s.driverName {
+		return status.Errorf(codes.PermissionDenied,
+			"only volumes of driver %s can be retrieved", s.driverName)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRestrictToDriver(t *testing.T) {
+	owned := newTestPVC("owned", "ns1", map[string]string{"app": "db"})
+	owned.Annotations = map[string]string{"volume.kubernetes.io/storage-provisioner": "csi.dell.com"}
+	bound := newTestPVC("bound", "ns1", map[string]string{"app": "web"})
+	bound.Spec.VolumeName = "pv-bound"
+	other := newTestPVC("other", "ns1", map[string]string{"app": "cache"})
+	other.Spec.StorageClassName = stringPtr("other")
+	// Bound to a PV of another driver, with an annotation claiming ours.
+	spoofed := newTestPVC("spoofed", "ns1", map[string]string{"app": "queue"})
+	spoofed.Annotations = map[string]string{"volume.kubernetes.io/storage-provisioner": "csi.dell.com"}
+	spoofed.Spec.VolumeName = "pv-spoofed"
+	fakeClientset := fake.NewSimpleClientset(
+		owned, bound, other, spoofed,
+		newTestPV("pv-bound", "csi.dell.com", "handle-bound", bound),
+		newTestPV("pv-spoofed", "csi.example.com", "handle-spoofed", spoofed),
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Provisioner: "csi.example.com"},
+	)
+	getClientset := func() (kubernetes.Interface, error) { return fakeClientset, nil }
+
+	t.Setenv(EnvVarDriverName, "csi.dell.com")
+	t.Setenv(EnvVarRestrictToDriver, "true")
+	client := createTestClient(getClientset)
+	require.True(t, client.restrictToDriver)
+	ctx := context.Background()
+
+	for _, name := range []string{"owned", "bound"} {
+		_, err := client.GetPVCLabels(ctx, &GetPVCLabelsRequest{Name: name, NameSpace: "ns1"})
+		assert.NoError(t, err, name)
+	}
+
+	for _, name := range []string{"other", "spoofed"} {
+		_, err := client.GetPVCLabels(ctx, &GetPVCLabelsRequest{Name: name, NameSpace: "ns1"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), name)
+	}
+	_, err := client.GetPVCStatus(ctx, &GetPVCStatusRequest{Name: "other", NameSpace: "ns1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err := client.GetPVCLabelsBatch(ctx, &GetPVCLabelsBatchRequest{
+		Keys: []*PVCKey{{Name: "owned", NameSpace: "ns1"}, {Name: "other", NameSpace: "ns1"}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Results[0].Error)
+	assert.Contains(t, resp.Results[1].Error, "is not provisioned by driver csi.dell.com")
+	assert.Nil(t, resp.Results[1].Parameters)
+
+	_, err = client.ListVolumeMetadata(ctx, &ListVolumeMetadataRequest{DriverName: "csi.example.com"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.ListVolumeMetadata(ctx, &ListVolumeMetadataRequest{})
+	assert.NoError(t, err)
+
+	// Group snapshot members of other drivers are left out.
+	members, err := client.GetVolumeGroupSnapshotMembers(ctx, &GetVolumeGroupSnapshotMembersRequest{
+		NameSpace:     "ns1",
+		LabelSelector: "app",
+	})
+	require.NoError(t, err)
+	names := []string{}
+	for _, m := range members.Members {
+		names = append(names, m.Name)
+	}
+	assert.ElementsMatch(t, []string{"owned", "bound"}, names)
+
+	// Without the restriction every PVC is answered.
+	t.Setenv(EnvVarRestrictToDriver, "false")
+	client = createTestClient(getClientset)
+	_, err = client.GetPVCLabels(ctx, &GetPVCLabelsRequest{Name: "other", NameSpace: "ns1"})
+	assert.NoError(t, err)
+}
+
+func TestRestrictToDriver_Env(t *testing.T) {
+	t.Setenv(EnvVarRestrictToDriver, "not-a-bool")
+	assert.False(t, createTestClient(FakeGetClientset).restrictToDriver)
+
+	// Restricting without a driver name denies every PVC.
+	t.Setenv(EnvVarRestrictToDriver, "1")
+	t.Setenv(EnvVarDriverName, "")
+	client := createTestClient(FakeGetClientset)
+	require.True(t, client.restrictToDriver)
+	err := client.checkPVCAccess(context.Background(), fake.NewSimpleClientset(), newTestPVC("pvc1", "ns1", nil))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -18,14 +18,21 @@ package retriever
 
 import (
 	"errors"
+	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -35,35 +42,148 @@ var restInClusterConfig = rest.InClusterConfig
 // MetadataRetrieverClient is the interface for retrieving metadata.
 type MetadataRetrieverClient interface {
 	GetPVCLabels(context.Context, *GetPVCLabelsRequest) (*GetPVCLabelsResponse, error)
+	WatchPVCMetadata(context.Context, *WatchPVCMetadataRequest) (WatchPVCMetadataClient, error)
+	GetPVCLabelsBatch(context.Context, *GetPVCLabelsBatchRequest) (*GetPVCLabelsBatchResponse, error)
+	ListVolumeMetadata(context.Context, *ListVolumeMetadataRequest) (*ListVolumeMetadataResponse, error)
+	GetOrphanedVolumes(context.Context, *GetOrphanedVolumesRequest) (*GetOrphanedVolumesResponse, error)
+	GetVolumeSnapshotMetadata(context.Context, *GetVolumeSnapshotMetadataRequest) (*GetVolumeSnapshotMetadataResponse, error)
+	GetVolumeSnapshotContentMetadata(context.Context, *GetVolumeSnapshotContentMetadataRequest) (*GetVolumeSnapshotContentMetadataResponse, error)
+	GetVolumeGroupSnapshotMembers(context.Context, *GetVolumeGroupSnapshotMembersRequest) (*GetVolumeGroupSnapshotMembersResponse, error)
+	GetPVCDataSource(context.Context, *GetPVCDataSourceRequest) (*GetPVCDataSourceResponse, error)
+	GetNodeMetadata(context.Context, *GetNodeMetadataRequest) (*GetNodeMetadataResponse, error)
+	GetPodMetadata(context.Context, *GetPodMetadataRequest) (*GetPodMetadataResponse, error)
+	GetPVCMetadataFromParameters(context.Context, *GetPVCMetadataFromParametersRequest) (*GetPVCMetadataResponse, error)
+	GetClusterInfo(context.Context, *GetClusterInfoRequest) (*GetClusterInfoResponse, error)
+	GetNamespaceStorageQuota(context.Context, *GetNamespaceStorageQuotaRequest) (*GetNamespaceStorageQuotaResponse, error)
+	GetPVCVolumeAttributesClass(context.Context, *GetPVCVolumeAttributesClassRequest) (*GetPVCVolumeAttributesClassResponse, error)
+	GetPVCStatus(context.Context, *GetPVCStatusRequest) (*GetPVCStatusResponse, error)
+	GetObjectMetadata(context.Context, *GetObjectMetadataRequest) (*GetObjectMetadataResponse, error)
+	AnnotateVolume(context.Context, *AnnotateVolumeRequest) (*AnnotateVolumeResponse, error)
 }
 
 // GetPVCLabelsRequest defines API request type
 type GetPVCLabelsRequest struct {
 	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	NameSpace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Filter overrides the configured default key filter.
+	Filter *KeyFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// SanitizationProfile overrides the configured default profile.
+	SanitizationProfile string `protobuf:"bytes,4,opt,name=sanitization_profile,proto3" json:"sanitization_profile,omitempty"`
 }
 
 // GetPVCLabelsResponse defines API response type
 type GetPVCLabelsResponse struct {
-	Parameters map[string]string `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Parameters   map[string]string     `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Sanitization []*SanitizationChange `protobuf:"bytes,5,rep,name=sanitization,proto3" json:"sanitization,omitempty"`
+	// Stale is set if the API server was unreachable and the labels were
+	// served from the persistent cache.
+	Stale bool `protobuf:"varint,6,opt,name=stale,proto3" json:"stale,omitempty"`
 }
 
 // MetadataRetrieverClientType holds client connection and timeout
 type MetadataRetrieverClientType struct {
-	conn         *grpc.ClientConn
-	timeout      time.Duration
-	getClientset func() (kubernetes.Interface, error)
+	conn             *grpc.ClientConn
+	timeout          time.Duration
+	getClientset     func() (kubernetes.Interface, error)
+	getDynamicClient func() (dynamic.Interface, error)
+	driverName       string
+	clusterName      string
+	restrictToDriver bool
+	emitEvents       bool
+	objectAllowlist  map[schema.GroupVersionResource]struct{}
+
+	// config is the configuration read from X_CSI_RETRIEVER_CONFIG_FILE.
+	// If it could not be loaded, configErr is returned by every request
+	// that depends on it.
+	config    *Config
+	configErr error
+
+	policies *policyStore
+
+	cacheMu       sync.RWMutex
+	informerCache *informerCache
+
+	// diskCache is nil unless X_CSI_RETRIEVER_CACHE_DIR is set.
+	diskCache *diskCache
+
+	clusterInfoMu sync.Mutex
+	clusterInfo   *GetClusterInfoResponse
+
+	nodeMu        sync.Mutex
+	nodeInformers map[string]*nodeInformer
+
+	eventMu     sync.Mutex
+	broadcaster record.EventBroadcaster
+	recorder    record.EventRecorder
+
+	stopCh    chan struct{}
+	closeOnce sync.Once
 }
 
 // NewMetadataRetrieverClient returns csiclient
 func NewMetadataRetrieverClient(conn *grpc.ClientConn, timeout time.Duration) *MetadataRetrieverClientType {
+	config, configErr := loadConfigFromEnv()
+	if configErr != nil {
+		log.Error("Error loading config: ", configErr)
+		config = &Config{}
+	}
+	if config.redactor.masksValues() {
+		logHookOnce.Do(func() {
+			log.AddHook(&redactionHook{redactor: config.redactor})
+		})
+	}
 	return &MetadataRetrieverClientType{
-		conn:         conn,
-		timeout:      timeout,
-		getClientset: defaultGetClientset,
+		conn:             conn,
+		timeout:          timeout,
+		getClientset:     defaultGetClientset,
+		getDynamicClient: defaultGetDynamicClient,
+		driverName:       os.Getenv(EnvVarDriverName),
+		clusterName:      os.Getenv(EnvVarClusterName),
+		restrictToDriver: restrictToDriver(),
+		emitEvents:       emitEvents(),
+		objectAllowlist:  parseObjectAllowlist(os.Getenv(EnvVarObjectAllowlist)),
+		config:           config,
+		configErr:        configErr,
+		policies:         &policyStore{policies: map[string]*metadataPolicy{}},
+		diskCache:        newDiskCacheFromEnv(config.redactor),
+		nodeInformers:    map[string]*nodeInformer{},
+		stopCh:           make(chan struct{}),
 	}
 }
 
+// restrictToDriver reads X_CSI_RETRIEVER_RESTRICT_TO_DRIVER.
+func restrictToDriver() bool {
+	restrict := envBool(EnvVarRestrictToDriver)
+	if restrict && os.Getenv(EnvVarDriverName) == "" {
+		log.Errorf("%s is set but %s is not; every PVC request will be denied",
+			EnvVarRestrictToDriver, EnvVarDriverName)
+	}
+	return restrict
+}
+
+// envBool reads a boolean environment variable, treating an unset or
+// invalid value as false.
+func envBool(name string) bool {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Warnf("Ignoring invalid %s value %q", name, v)
+		return false
+	}
+	return b
+}
+
+// Close stops the informers started on behalf of the client.
+func (s *MetadataRetrieverClientType) Close() {
+	s.closeOnce.Do(func() {
+		close(s.stopCh)
+		s.stopEvents()
+	})
+}
+
 func defaultGetClientset() (kubernetes.Interface, error) {
 	config, err := restInClusterConfig()
 	if err != nil {
@@ -72,6 +192,14 @@ func defaultGetClientset() (kubernetes.Interface, error) {
 	return kubernetes.NewForConfig(config)
 }
 
+func defaultGetDynamicClient() (dynamic.Interface, error) {
+	config, err := restInClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(config)
+}
+
 // GetPVCLabels gets the PVC labels and returns it
 func (s *MetadataRetrieverClientType) GetPVCLabels(
 	ctx context.Context,
@@ -84,6 +212,12 @@ func (s *MetadataRetrieverClientType) GetPVCLabels(
 			"PVC Name cannot be empty")
 	}
 
+	output, err := s.outputFor(req.Filter, req.SanitizationProfile)
+	if err != nil {
+		log.Error("Error reading output options: ", err)
+		return nil, err
+	}
+
 	clientset, err := s.getClientset()
 	if err != nil {
 		log.Error("Error creating clientset: ", err)
@@ -97,20 +231,49 @@ func (s *MetadataRetrieverClientType) GetPVCLabels(
 	}
 
 	pvc, err := pvcClient.Get(ctx, req.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		s.diskCache.remove(req.NameSpace, req.Name)
+	}
 	if err != nil {
+		if cached, cachedOutput, _, ok := s.fromDiskCache(ctx, req.NameSpace, req.Name, output, err); ok {
+			parameters, changes := cachedOutput.apply("labels", cached.Labels)
+			return &GetPVCLabelsResponse{Parameters: parameters, Sanitization: changes, Stale: true}, nil
+		}
 		log.Error("Error retrieving PVC info: ", err)
+		s.recordPVCEvent(clientset, pvcReference(req.NameSpace, req.Name), err)
 		return nil, err
 	}
 
-	parameters := make(map[string]string)
+	if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+		log.Error("Error checking PVC access: ", err)
+		s.recordPVCEvent(clientset, pvc, err)
+		return nil, err
+	}
 
-	for k, v := range pvc.Labels {
-		parameters[k] = v
+	output, rule, err := s.outputForPVC(ctx, clientset, nil, output, pvc)
+	if err != nil {
+		log.Error("Error matching MetadataPolicy: ", err)
+		s.recordPVCEvent(clientset, pvc, err)
+		return nil, err
 	}
 
+	parameters, changes := output.apply("labels", pvc.Labels)
+	s.recordPVCEvent(clientset, pvc, nil)
+	s.diskCache.store(pvc, output.namespace, rule)
+
 	resp := &GetPVCLabelsResponse{
-		Parameters: parameters,
+		Parameters:   parameters,
+		Sanitization: changes,
 	}
 
 	return resp, err
 }
+
+// copyMap returns a copy of m that is safe to hand out to callers.
+func copyMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -30,6 +30,8 @@ import (
 	"k8s.io/client-go/rest"
 )
 
+var _ MetadataRetrieverClient = &MetadataRetrieverClientType{}
+
 type pvcNilClientset struct {
 	*fake.Clientset
 }
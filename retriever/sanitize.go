@@ -0,0 +1,216 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Sanitization change reasons reported in SanitizationChange.Reasons
+const (
+	SanitizeKeyLowercased       = "KeyLowercased"
+	SanitizeValueLowercased     = "ValueLowercased"
+	SanitizeKeyCharsReplaced    = "KeyCharactersReplaced"
+	SanitizeValueCharsReplaced  = "ValueCharactersReplaced"
+	SanitizeKeyTruncated        = "KeyTruncated"
+	SanitizeValueTruncated      = "ValueTruncated"
+	SanitizeDroppedDuplicateKey = "DroppedDuplicateKey"
+	SanitizeDroppedTagLimit     = "DroppedTagLimit"
+	SanitizeDroppedEmptyKey     = "DroppedEmptyKey"
+)
+
+// hashSuffixLength is the number of hex digits of the SHA-256 of the
+// original string appended to truncated keys and values.
+const hashSuffixLength = 8
+
+// SanitizationProfile describes the tag constraints of a storage backend.
+// Zero values mean no constraint.
+type SanitizationProfile struct {
+	// MaxKeyLength and MaxValueLength are in characters. Longer keys and
+	// values are truncated and end in "-" and a hash of the original, so
+	// that distinct inputs stay distinct.
+	MaxKeyLength   int `json:"maxKeyLength,omitempty"`
+	MaxValueLength int `json:"maxValueLength,omitempty"`
+	// AllowedCharacters is the body of a regular expression character
+	// class, e.g. "a-zA-Z0-9_.-". Other characters are replaced with
+	// Replacement, which defaults to "_", or removed if "_" is not allowed
+	// either.
+	AllowedCharacters string `json:"allowedCharacters,omitempty"`
+	Replacement       string `json:"replacement,omitempty"`
+	// LowercaseKeys and LowercaseValues are for case-insensitive backends.
+	LowercaseKeys   bool `json:"lowercaseKeys,omitempty"`
+	LowercaseValues bool `json:"lowercaseValues,omitempty"`
+	// MaxTags caps the number of entries. Entries whose original key
+	// starts with an earlier Priority prefix are kept first, then the
+	// rest in key order.
+	MaxTags  int      `json:"maxTags,omitempty"`
+	Priority []string `json:"priority,omitempty"`
+}
+
+// SanitizationChange reports how an entry was changed by a sanitization
+// profile. SanitizedKey is empty if the entry was dropped.
+type SanitizationChange struct {
+	Field        string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Key          string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	SanitizedKey string   `protobuf:"bytes,3,opt,name=sanitized_key,proto3" json:"sanitized_key,omitempty"`
+	Reasons      []string `protobuf:"bytes,4,rep,name=reasons,proto3" json:"reasons,omitempty"`
+}
+
+// sanitizer is a compiled SanitizationProfile
+type sanitizer struct {
+	profile     *SanitizationProfile
+	disallowed  *regexp.Regexp
+	replacement string
+}
+
+func compileSanitizationProfile(p *SanitizationProfile) (*sanitizer, error) {
+	if p == nil {
+		return nil, nil
+	}
+	if p.MaxKeyLength < 0 || p.MaxValueLength < 0 || p.MaxTags < 0 {
+		return nil, fmt.Errorf("lengths and tag count cannot be negative")
+	}
+	s := &sanitizer{profile: p}
+	if p.AllowedCharacters != "" {
+		re, err := regexp.Compile("[^" + p.AllowedCharacters + "]")
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowedCharacters %q: %v", p.AllowedCharacters, err)
+		}
+		if p.Replacement != "" && re.MatchString(p.Replacement) {
+			return nil, fmt.Errorf("replacement %q is not an allowed character", p.Replacement)
+		}
+		s.disallowed = re
+		s.replacement = p.Replacement
+		if s.replacement == "" && !re.MatchString("_") {
+			s.replacement = "_"
+		}
+	}
+	return s, nil
+}
+
+// apply sanitizes m, which holds the entries of field, and reports every
+// entry it changed or dropped.
+func (s *sanitizer) apply(field string, m map[string]string) (map[string]string, []*SanitizationChange) {
+	if s == nil {
+		return m, nil
+	}
+	p := s.profile
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		pi, pj := s.priority(keys[i]), s.priority(keys[j])
+		if pi != pj {
+			return pi < pj
+		}
+		return keys[i] < keys[j]
+	})
+
+	out := make(map[string]string, len(m))
+	var changes []*SanitizationChange
+	for _, k := range keys {
+		change := &SanitizationChange{Field: field, Key: k}
+		key := s.sanitize(k, p.LowercaseKeys, p.MaxKeyLength, change,
+			SanitizeKeyLowercased, SanitizeKeyCharsReplaced, SanitizeKeyTruncated)
+		value := s.sanitize(m[k], p.LowercaseValues, p.MaxValueLength, change,
+			SanitizeValueLowercased, SanitizeValueCharsReplaced, SanitizeValueTruncated)
+
+		switch _, dup := out[key]; {
+		case key == "":
+			change.Reasons = append(change.Reasons, SanitizeDroppedEmptyKey)
+		case dup:
+			change.Reasons = append(change.Reasons, SanitizeDroppedDuplicateKey)
+		case p.MaxTags > 0 && len(out) >= p.MaxTags:
+			change.Reasons = append(change.Reasons, SanitizeDroppedTagLimit)
+		default:
+			out[key] = value
+			change.SanitizedKey = key
+		}
+		if len(change.Reasons) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return out, changes
+}
+
+// sanitize lowercases, replaces disallowed characters and truncates str,
+// recording the reason for each change.
+func (s *sanitizer) sanitize(str string, lower bool, maxLength int, change *SanitizationChange,
+	lowered, replaced, truncated string,
+) string {
+	original := str
+	if lower && strings.ToLower(str) != str {
+		str = strings.ToLower(str)
+		change.Reasons = append(change.Reasons, lowered)
+	}
+	if s.disallowed != nil && s.disallowed.MatchString(str) {
+		str = s.replaceDisallowed(str)
+		change.Reasons = append(change.Reasons, replaced)
+	}
+	if maxLength > 0 && len([]rune(str)) > maxLength {
+		str = s.truncateWithHash(str, original, maxLength, lower)
+		change.Reasons = append(change.Reasons, truncated)
+	}
+	return str
+}
+
+func (s *sanitizer) replaceDisallowed(str string) string {
+	if s.disallowed == nil {
+		return str
+	}
+	return s.disallowed.ReplaceAllLiteralString(str, s.replacement)
+}
+
+// truncateWithHash shortens str to maxLength characters, ending it in "-"
+// and a hash of original when there is room for one. The suffix goes
+// through the same character replacement as str, using uppercase hex
+// digits if only those are allowed.
+func (s *sanitizer) truncateWithHash(str, original string, maxLength int, lower bool) string {
+	runes := []rune(str)
+	if maxLength <= hashSuffixLength+1 {
+		return string(runes[:maxLength])
+	}
+	sum := sha256.Sum256([]byte(original))
+	digits := hex.EncodeToString(sum[:])[:hashSuffixLength]
+	if s.disallowed != nil && !lower && s.disallowed.MatchString(digits) &&
+		!s.disallowed.MatchString(strings.ToUpper(digits)) {
+		digits = strings.ToUpper(digits)
+	}
+	// A multi-character replacement can make the suffix longer.
+	suffix := []rune(s.replaceDisallowed("-" + digits))
+	if len(suffix) >= maxLength {
+		return string(runes[:maxLength])
+	}
+	return string(runes[:maxLength-len(suffix)]) + string(suffix)
+}
+
+// priority returns the index of the first Priority prefix of key, or the
+// number of prefixes if none matches.
+func (s *sanitizer) priority(key string) int {
+	for i, prefix := range s.profile.Priority {
+		if strings.HasPrefix(key, prefix) {
+			return i
+		}
+	}
+	return len(s.profile.Priority)
+}
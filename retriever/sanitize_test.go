@@ -0,0 +1,177 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testHashSuffix(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+func TestSanitizerApply(t *testing.T) {
+	s, err := compileSanitizationProfile(&SanitizationProfile{
+		MaxKeyLength:      16,
+		MaxValueLength:    12,
+		AllowedCharacters: "a-z0-9_.-",
+		LowercaseKeys:     true,
+		MaxTags:           3,
+		Priority:          []string{"storage.example.com/", "tier"},
+	})
+	require.NoError(t, err)
+
+	out, changes := s.apply("labels", map[string]string{
+		"storage.example.com/owner": "team-a",
+		"Tier":                      "gold",
+		"tier":                      "silver",
+		"app":                       "a very long application name",
+		"zone":                      "east",
+	})
+	assert.Equal(t, map[string]string{
+		"storage-" + testHashSuffix("storage.example.com/owner"): "team-a",
+		"tier": "silver",
+		"app":  "a_v-" + testHashSuffix("a very long application name"),
+	}, out)
+	assert.Equal(t, []*SanitizationChange{
+		{Field: "labels", Key: "storage.example.com/owner", SanitizedKey: "storage-" + testHashSuffix("storage.example.com/owner"),
+			Reasons: []string{SanitizeKeyCharsReplaced, SanitizeKeyTruncated}},
+		{Field: "labels", Key: "Tier", Reasons: []string{SanitizeKeyLowercased, SanitizeDroppedDuplicateKey}},
+		{Field: "labels", Key: "app", SanitizedKey: "app",
+			Reasons: []string{SanitizeValueCharsReplaced, SanitizeValueTruncated}},
+		{Field: "labels", Key: "zone", Reasons: []string{SanitizeDroppedTagLimit}},
+	}, changes)
+
+	// Output is deterministic across calls.
+	again, _ := s.apply("labels", map[string]string{"app": "a very long application name"})
+	assert.Equal(t, out["app"], again["app"])
+
+	var nilSanitizer *sanitizer
+	out, changes = nilSanitizer.apply("labels", map[string]string{"k": "v"})
+	assert.Equal(t, map[string]string{"k": "v"}, out)
+	assert.Nil(t, changes)
+}
+
+func TestTruncateWithHash(t *testing.T) {
+	s := &sanitizer{profile: &SanitizationProfile{}}
+	assert.Equal(t, "abcd", s.truncateWithHash("abcdefghijkl", "abcdefghijkl", 4, false))
+	assert.Equal(t, "ab-"+testHashSuffix("abcdefghijkl"), s.truncateWithHash("abcdefghijkl", "abcdefghijkl", 11, false))
+	assert.Equal(t, "é-"+testHashSuffix("ééééééééééé"), s.truncateWithHash("ééééééééééé", "ééééééééééé", 10, false))
+
+	s, err := compileSanitizationProfile(&SanitizationProfile{AllowedCharacters: "A-Z0-9"})
+	require.NoError(t, err)
+	assert.Equal(t, "ABCD"+strings.ToUpper(testHashSuffix("ABCDEFGHIJKL")),
+		s.truncateWithHash("ABCDEFGHIJKL", "ABCDEFGHIJKL", 12, false))
+}
+
+func TestSanitizerApply_OutputFitsProfile(t *testing.T) {
+	input := map[string]string{
+		"app.kubernetes.io/name":               "Database-Primary-With-A-Long-Name",
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ":           "0123456789ABCDEFGHIJ",
+		"team":                                 "Storage Team",
+		"kubectl.kubernetes.io/last-applied":   "{}",
+		"example.com/ünïcödé-key-that-is-long": "välue",
+	}
+	for _, p := range []*SanitizationProfile{
+		{AllowedCharacters: "A-Z0-9", MaxKeyLength: 12, MaxValueLength: 12},
+		{AllowedCharacters: "A-Z0-9_", Replacement: "_", MaxKeyLength: 16, MaxValueLength: 10},
+		{AllowedCharacters: "a-z0-9", Replacement: "x", LowercaseKeys: true, LowercaseValues: true, MaxKeyLength: 10, MaxValueLength: 10},
+		{AllowedCharacters: "a-zA-Z0-9_.:/=+@ -", MaxKeyLength: 20, MaxValueLength: 14},
+		{AllowedCharacters: "a-z0-9", Replacement: "xx", MaxKeyLength: 16, MaxValueLength: 12},
+		{AllowedCharacters: "a-z", Replacement: "xyz", MaxKeyLength: 8, MaxValueLength: 4},
+	} {
+		s, err := compileSanitizationProfile(p)
+		require.NoError(t, err)
+		out, _ := s.apply("labels", input)
+		for k, v := range out {
+			for _, str := range []string{k, v} {
+				assert.False(t, s.disallowed.MatchString(str), "%q does not fit %+v", str, p)
+			}
+			assert.NotEmpty(t, k)
+			assert.LessOrEqual(t, len([]rune(k)), p.MaxKeyLength, k)
+			assert.LessOrEqual(t, len([]rune(v)), p.MaxValueLength, v)
+			if p.LowercaseKeys {
+				assert.Equal(t, strings.ToLower(k), k)
+			}
+			if p.LowercaseValues {
+				assert.Equal(t, strings.ToLower(v), v)
+			}
+		}
+	}
+}
+
+func TestSanitizerApply_EmptyKey(t *testing.T) {
+	s, err := compileSanitizationProfile(&SanitizationProfile{AllowedCharacters: "a-z"})
+	require.NoError(t, err)
+	out, changes := s.apply("labels", map[string]string{"///": "x", "..": "y", "app": "db"})
+	assert.Equal(t, map[string]string{"app": "db"}, out)
+	assert.Equal(t, []*SanitizationChange{
+		{Field: "labels", Key: "..", Reasons: []string{SanitizeKeyCharsReplaced, SanitizeDroppedEmptyKey}},
+		{Field: "labels", Key: "///", Reasons: []string{SanitizeKeyCharsReplaced, SanitizeDroppedEmptyKey}},
+	}, changes)
+}
+
+func TestCompileSanitizationProfile(t *testing.T) {
+	_, err := compileSanitizationProfile(&SanitizationProfile{MaxTags: -1})
+	assert.Error(t, err)
+	_, err = compileSanitizationProfile(&SanitizationProfile{AllowedCharacters: "z-a"})
+	assert.ErrorContains(t, err, "invalid allowedCharacters")
+	_, err = compileSanitizationProfile(&SanitizationProfile{AllowedCharacters: "a-z", Replacement: "-"})
+	assert.ErrorContains(t, err, "replacement")
+}
+
+func TestGetPVCLabels_SanitizationProfile(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(newTestPVC("pvc1", "ns1", map[string]string{"Team": "A"}))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.config = &Config{
+		SanitizationProfiles: map[string]*SanitizationProfile{
+			"lower": {LowercaseKeys: true, LowercaseValues: true},
+			"upper": {AllowedCharacters: "A-Z"},
+		},
+		DefaultSanitizationProfile: "lower",
+	}
+	require.NoError(t, client.config.compile())
+
+	resp, err := client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "a"}, resp.Parameters)
+	assert.Equal(t, []*SanitizationChange{{
+		Field: "labels", Key: "Team", SanitizedKey: "team",
+		Reasons: []string{SanitizeKeyLowercased, SanitizeValueLowercased},
+	}}, resp.Sanitization)
+
+	resp, err = client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1", SanitizationProfile: "upper"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"T": "A"}, resp.Parameters)
+
+	_, err = client.GetPVCLabels(context.Background(), &GetPVCLabelsRequest{Name: "pvc1", NameSpace: "ns1", SanitizationProfile: "missing"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	client.config = &Config{DefaultSanitizationProfile: "missing"}
+	assert.ErrorContains(t, client.config.compile(), "default sanitization profile missing is not defined")
+}
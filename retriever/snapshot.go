@@ -0,0 +1,150 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	volumeSnapshotGVR = schema.GroupVersionResource{
+		Group:    "snapshot.storage.k8s.io",
+		Version:  "v1",
+		Resource: "volumesnapshots",
+	}
+	volumeSnapshotContentGVR = schema.GroupVersionResource{
+		Group:    "snapshot.storage.k8s.io",
+		Version:  "v1",
+		Resource: "volumesnapshotcontents",
+	}
+)
+
+// GetVolumeSnapshotMetadataRequest defines API request type
+type GetVolumeSnapshotMetadataRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+// GetVolumeSnapshotMetadataResponse defines API response type
+type GetVolumeSnapshotMetadataResponse struct {
+	Name                    string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace               string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID                     string            `protobuf:"bytes,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Labels                  map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations             map[string]string `protobuf:"bytes,5,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SourcePVCName           string            `protobuf:"bytes,6,opt,name=source_pvc_name,proto3" json:"source_pvc_name,omitempty"`
+	VolumeSnapshotClassName string            `protobuf:"bytes,7,opt,name=volume_snapshot_class_name,proto3" json:"volume_snapshot_class_name,omitempty"`
+	ContentName             string            `protobuf:"bytes,8,opt,name=content_name,proto3" json:"content_name,omitempty"`
+}
+
+// GetVolumeSnapshotContentMetadataRequest defines API request type
+type GetVolumeSnapshotContentMetadataRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+// GetVolumeSnapshotContentMetadataResponse defines API response type
+type GetVolumeSnapshotContentMetadataResponse struct {
+	Name                    string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	UID                     string            `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Labels                  map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations             map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Driver                  string            `protobuf:"bytes,5,opt,name=driver,proto3" json:"driver,omitempty"`
+	DeletionPolicy          string            `protobuf:"bytes,6,opt,name=deletion_policy,proto3" json:"deletion_policy,omitempty"`
+	SnapshotHandle          string            `protobuf:"bytes,7,opt,name=snapshot_handle,proto3" json:"snapshot_handle,omitempty"`
+	SourceVolumeHandle      string            `protobuf:"bytes,8,opt,name=source_volume_handle,proto3" json:"source_volume_handle,omitempty"`
+	VolumeSnapshotName      string            `protobuf:"bytes,9,opt,name=volume_snapshot_name,proto3" json:"volume_snapshot_name,omitempty"`
+	VolumeSnapshotNameSpace string            `protobuf:"bytes,10,opt,name=volume_snapshot_namespace,proto3" json:"volume_snapshot_namespace,omitempty"`
+}
+
+// GetVolumeSnapshotMetadata gets the metadata of a VolumeSnapshot. If the
+// snapshot CRDs are not installed an Unimplemented error is returned.
+func (s *MetadataRetrieverClientType) GetVolumeSnapshotMetadata(
+	ctx context.Context,
+	req *GetVolumeSnapshotMetadataRequest) (
+	*GetVolumeSnapshotMetadataResponse, error,
+) {
+	log.Infof("Get VolumeSnapshot metadata for %s in namespace %s", req.Name, req.NameSpace)
+	if req.Name == "" {
+		return nil, errors.New("VolumeSnapshot Name cannot be empty")
+	}
+
+	snap, err := s.getDynamicObject(ctx, volumeSnapshotGVR, req.NameSpace, req.Name)
+	if err != nil {
+		log.Error("Error retrieving VolumeSnapshot info: ", err)
+		return nil, err
+	}
+
+	resp := &GetVolumeSnapshotMetadataResponse{
+		Name:        snap.GetName(),
+		NameSpace:   snap.GetNamespace(),
+		UID:         string(snap.GetUID()),
+		Labels:      s.redactMap(snap.GetLabels()),
+		Annotations: s.redactMap(snap.GetAnnotations()),
+	}
+	resp.SourcePVCName, _, _ = unstructured.NestedString(snap.Object, "spec", "source", "persistentVolumeClaimName")
+	resp.VolumeSnapshotClassName, _, _ = unstructured.NestedString(snap.Object, "spec", "volumeSnapshotClassName")
+	resp.ContentName, _, _ = unstructured.NestedString(snap.Object, "status", "boundVolumeSnapshotContentName")
+	if resp.ContentName == "" {
+		resp.ContentName, _, _ = unstructured.NestedString(snap.Object, "spec", "source", "volumeSnapshotContentName")
+	}
+
+	return resp, nil
+}
+
+// GetVolumeSnapshotContentMetadata gets the metadata of a
+// VolumeSnapshotContent. If the snapshot CRDs are not installed an
+// Unimplemented error is returned.
+func (s *MetadataRetrieverClientType) GetVolumeSnapshotContentMetadata(
+	ctx context.Context,
+	req *GetVolumeSnapshotContentMetadataRequest) (
+	*GetVolumeSnapshotContentMetadataResponse, error,
+) {
+	log.Infof("Get VolumeSnapshotContent metadata for %s", req.Name)
+	if req.Name == "" {
+		return nil, errors.New("VolumeSnapshotContent Name cannot be empty")
+	}
+
+	content, err := s.getDynamicObject(ctx, volumeSnapshotContentGVR, "", req.Name)
+	if err != nil {
+		log.Error("Error retrieving VolumeSnapshotContent info: ", err)
+		return nil, err
+	}
+
+	resp := &GetVolumeSnapshotContentMetadataResponse{
+		Name:        content.GetName(),
+		UID:         string(content.GetUID()),
+		Labels:      s.redactMap(content.GetLabels()),
+		Annotations: s.redactMap(content.GetAnnotations()),
+	}
+	resp.Driver, _, _ = unstructured.NestedString(content.Object, "spec", "driver")
+	resp.DeletionPolicy, _, _ = unstructured.NestedString(content.Object, "spec", "deletionPolicy")
+	resp.SourceVolumeHandle, _, _ = unstructured.NestedString(content.Object, "spec", "source", "volumeHandle")
+	resp.VolumeSnapshotName, _, _ = unstructured.NestedString(content.Object, "spec", "volumeSnapshotRef", "name")
+	resp.VolumeSnapshotNameSpace, _, _ = unstructured.NestedString(content.Object, "spec", "volumeSnapshotRef", "namespace")
+	resp.SnapshotHandle, _, _ = unstructured.NestedString(content.Object, "status", "snapshotHandle")
+	if resp.SnapshotHandle == "" {
+		// Pre-provisioned contents carry the handle in the spec.
+		resp.SnapshotHandle, _, _ = unstructured.NestedString(content.Object, "spec", "source", "snapshotHandle")
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,162 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var snapshotAPIResources = &metav1.APIResourceList{
+	GroupVersion: "snapshot.storage.k8s.io/v1",
+	APIResources: []metav1.APIResource{
+		{Name: "volumesnapshots", Namespaced: true, Kind: "VolumeSnapshot"},
+		{Name: "volumesnapshotcontents", Namespaced: false, Kind: "VolumeSnapshotContent"},
+	},
+}
+
+func newTestVolumeSnapshot(name, namespace, pvcName string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshot",
+		"metadata": map[string]interface{}{
+			"name":        name,
+			"namespace":   namespace,
+			"uid":         "uid-" + name,
+			"labels":      map[string]interface{}{"backup": "daily"},
+			"annotations": map[string]interface{}{"owner": "team-a"},
+		},
+		"spec": map[string]interface{}{
+			"volumeSnapshotClassName": "dell-snapclass",
+			"source": map[string]interface{}{
+				"persistentVolumeClaimName": pvcName,
+			},
+		},
+		"status": map[string]interface{}{
+			"boundVolumeSnapshotContentName": "snapcontent-" + name,
+		},
+	}}
+}
+
+func createTestDynamicClient(fakeClientset *fake.Clientset, objects ...runtime.Object) *MetadataRetrieverClientType {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.getDynamicClient = func() (dynamic.Interface, error) { return dynamicClient, nil }
+	return client
+}
+
+func TestGetVolumeSnapshotMetadata(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset()
+	fakeClientset.Resources = []*metav1.APIResourceList{snapshotAPIResources}
+	client := createTestDynamicClient(fakeClientset, newTestVolumeSnapshot("snap1", "ns1", "pvc1"))
+
+	resp, err := client.GetVolumeSnapshotMetadata(context.Background(), &GetVolumeSnapshotMetadataRequest{Name: "snap1", NameSpace: "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, "ns1", resp.NameSpace)
+	assert.Equal(t, "uid-snap1", resp.UID)
+	assert.Equal(t, map[string]string{"backup": "daily"}, resp.Labels)
+	assert.Equal(t, map[string]string{"owner": "team-a"}, resp.Annotations)
+	assert.Equal(t, "pvc1", resp.SourcePVCName)
+	assert.Equal(t, "dell-snapclass", resp.VolumeSnapshotClassName)
+	assert.Equal(t, "snapcontent-snap1", resp.ContentName)
+
+	_, err = client.GetVolumeSnapshotMetadata(context.Background(), &GetVolumeSnapshotMetadataRequest{Name: "missing", NameSpace: "ns1"})
+	assert.Contains(t, err.Error(), "not found")
+	assert.Equal(t, codes.Unknown, status.Code(err))
+
+	_, err = client.GetVolumeSnapshotMetadata(context.Background(), &GetVolumeSnapshotMetadataRequest{})
+	assert.EqualError(t, err, "VolumeSnapshot Name cannot be empty")
+}
+
+func TestGetVolumeSnapshotContentMetadata(t *testing.T) {
+	content := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshotContent",
+		"metadata": map[string]interface{}{
+			"name":   "snapcontent-snap1",
+			"uid":    "uid-content",
+			"labels": map[string]interface{}{"backup": "daily"},
+		},
+		"spec": map[string]interface{}{
+			"driver":         "csi.dell.com",
+			"deletionPolicy": "Delete",
+			"source": map[string]interface{}{
+				"volumeHandle": "handle-1",
+			},
+			"volumeSnapshotRef": map[string]interface{}{
+				"name":      "snap1",
+				"namespace": "ns1",
+			},
+		},
+		"status": map[string]interface{}{
+			"snapshotHandle": "snap-handle-1",
+		},
+	}}
+	preProvisioned := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshotContent",
+		"metadata":   map[string]interface{}{"name": "static"},
+		"spec": map[string]interface{}{
+			"source": map[string]interface{}{
+				"snapshotHandle": "snap-handle-2",
+			},
+		},
+	}}
+	fakeClientset := fake.NewSimpleClientset()
+	fakeClientset.Resources = []*metav1.APIResourceList{snapshotAPIResources}
+	client := createTestDynamicClient(fakeClientset, content, preProvisioned)
+
+	resp, err := client.GetVolumeSnapshotContentMetadata(context.Background(), &GetVolumeSnapshotContentMetadataRequest{Name: "snapcontent-snap1"})
+	require.NoError(t, err)
+	assert.Equal(t, "uid-content", resp.UID)
+	assert.Equal(t, map[string]string{"backup": "daily"}, resp.Labels)
+	assert.Equal(t, "csi.dell.com", resp.Driver)
+	assert.Equal(t, "Delete", resp.DeletionPolicy)
+	assert.Equal(t, "handle-1", resp.SourceVolumeHandle)
+	assert.Equal(t, "snap-handle-1", resp.SnapshotHandle)
+	assert.Equal(t, "snap1", resp.VolumeSnapshotName)
+	assert.Equal(t, "ns1", resp.VolumeSnapshotNameSpace)
+
+	resp, err = client.GetVolumeSnapshotContentMetadata(context.Background(), &GetVolumeSnapshotContentMetadataRequest{Name: "static"})
+	require.NoError(t, err)
+	assert.Equal(t, "snap-handle-2", resp.SnapshotHandle)
+
+	_, err = client.GetVolumeSnapshotContentMetadata(context.Background(), &GetVolumeSnapshotContentMetadataRequest{})
+	assert.EqualError(t, err, "VolumeSnapshotContent Name cannot be empty")
+}
+
+func TestGetVolumeSnapshotMetadata_CRDsNotInstalled(t *testing.T) {
+	client := createTestDynamicClient(fake.NewSimpleClientset())
+
+	_, err := client.GetVolumeSnapshotMetadata(context.Background(), &GetVolumeSnapshotMetadataRequest{Name: "snap1", NameSpace: "ns1"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = client.GetVolumeSnapshotContentMetadata(context.Background(), &GetVolumeSnapshotContentMetadataRequest{Name: "content1"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
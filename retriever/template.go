@@ -0,0 +1,141 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"context"
+	"strings"
+	"text/template"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// TemplateData is the data templates are rendered against, e.g.
+// "{{.Namespace}}-{{.Labels.app}}-{{.PVCName}}". Missing map keys render
+// as empty strings.
+type TemplateData struct {
+	Namespace              string
+	PVCName                string
+	PVCUID                 string
+	PVName                 string
+	StorageClassName       string
+	Labels                 map[string]string
+	Annotations            map[string]string
+	NamespaceLabels        map[string]string
+	NamespaceAnnotations   map[string]string
+	StorageClassLabels     map[string]string
+	StorageClassParameters map[string]string
+}
+
+// compileTemplates parses the named templates of the configuration.
+func compileTemplates(templates map[string]string) (map[string]*template.Template, error) {
+	compiled := make(map[string]*template.Template, len(templates))
+	for name, text := range templates {
+		t, err := template.New(name).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		compiled[name] = t
+	}
+	return compiled, nil
+}
+
+// templateData collects the PVC, namespace and StorageClass metadata of a
+// PVC, with the configured redaction applied.
+func (s *MetadataRetrieverClientType) templateData(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	pvc *v1.PersistentVolumeClaim,
+	pvName string,
+) (*TemplateData, error) {
+	data := &TemplateData{
+		Namespace:   pvc.Namespace,
+		PVCName:     pvc.Name,
+		PVCUID:      string(pvc.UID),
+		PVName:      pvName,
+		Labels:      s.redactMap(pvc.Labels),
+		Annotations: s.redactMap(pvc.Annotations),
+	}
+
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, pvc.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	data.NamespaceLabels = s.redactMap(ns.Labels)
+	data.NamespaceAnnotations = s.redactMap(ns.Annotations)
+
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+		data.StorageClassName = *pvc.Spec.StorageClassName
+		sc, err := clientset.StorageV1().StorageClasses().Get(ctx, data.StorageClassName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data.StorageClassLabels = s.redactMap(sc.Labels)
+		data.StorageClassParameters = copyMap(sc.Parameters)
+	} else {
+		data.StorageClassLabels = map[string]string{}
+		data.StorageClassParameters = map[string]string{}
+	}
+	return data, nil
+}
+
+// templatesFor returns the templates with the given names, or all of them
+// if names is empty. The templates of a matching policy rule are added to
+// the configured ones and replace those with the same names.
+func (s *MetadataRetrieverClientType) templatesFor(names []string, rule *policyRule) (map[string]*template.Template, error) {
+	if s.configErr != nil {
+		return nil, s.configErr
+	}
+	available := s.config.templates
+	if rule != nil && len(rule.templates) > 0 {
+		available = make(map[string]*template.Template, len(s.config.templates)+len(rule.templates))
+		for name, t := range s.config.templates {
+			available[name] = t
+		}
+		for name, t := range rule.templates {
+			available[name] = t
+		}
+	}
+	if len(names) == 0 {
+		return available, nil
+	}
+	templates := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		t, ok := available[name]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown template %q", name)
+		}
+		templates[name] = t
+	}
+	return templates, nil
+}
+
+// renderTemplates renders every template against data.
+func renderTemplates(templates map[string]*template.Template, data *TemplateData) (map[string]string, error) {
+	rendered := make(map[string]string, len(templates))
+	for name, t := range templates {
+		var sb strings.Builder
+		if err := t.Execute(&sb, data); err != nil {
+			return nil, err
+		}
+		rendered[name] = sb.String()
+	}
+	return rendered, nil
+}
@@ -0,0 +1,88 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPVCMetadataFromParameters_Templates(t *testing.T) {
+	pvc := newTestPVC("pvc1", "ns1", map[string]string{"app": "db"})
+	pvc.Spec.StorageClassName = stringPtr("gold")
+	fakeClientset := fake.NewSimpleClientset(pvc,
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"tenant": "acme"}}},
+		&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "gold"},
+			Parameters: map[string]string{"arrayID": "array-1"},
+		},
+	)
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.config = &Config{Templates: map[string]string{
+		"volumeName":  "{{.Namespace}}-{{.Labels.app}}-{{.PVCName}}",
+		"description": "{{.NamespaceLabels.tenant}} {{.StorageClassName}} {{.StorageClassParameters.arrayID}} {{.PVName}}{{.Labels.missing}}",
+	}}
+	require.NoError(t, client.config.compile())
+
+	resp, err := client.GetPVCMetadataFromParameters(context.Background(),
+		&GetPVCMetadataFromParametersRequest{Parameters: testCreateVolumeParameters()})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"volumeName":  "ns1-db-pvc1",
+		"description": "acme gold array-1 pvc-1234",
+	}, resp.Rendered)
+
+	resp, err = client.GetPVCMetadataFromParameters(context.Background(),
+		&GetPVCMetadataFromParametersRequest{Parameters: testCreateVolumeParameters(), Templates: []string{"volumeName"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"volumeName": "ns1-db-pvc1"}, resp.Rendered)
+
+	_, err = client.GetPVCMetadataFromParameters(context.Background(),
+		&GetPVCMetadataFromParametersRequest{Parameters: testCreateVolumeParameters(), Templates: []string{"missing"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetPVCMetadataFromParameters_TemplateErrors(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(newTestPVC("pvc1", "ns1", nil))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	client.config = &Config{Templates: map[string]string{"volumeName": "{{.PVCName}}"}}
+	require.NoError(t, client.config.compile())
+
+	// The namespace does not exist.
+	_, err := client.GetPVCMetadataFromParameters(context.Background(),
+		&GetPVCMetadataFromParametersRequest{Parameters: testCreateVolumeParameters()})
+	assert.ErrorContains(t, err, "not found")
+
+	require.NoError(t, fakeClientset.Tracker().Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}}))
+	client.config = &Config{Templates: map[string]string{"bad": "{{.PVCName.Missing}}"}}
+	require.NoError(t, client.config.compile())
+	_, err = client.GetPVCMetadataFromParameters(context.Background(),
+		&GetPVCMetadataFromParametersRequest{Parameters: testCreateVolumeParameters()})
+	assert.ErrorContains(t, err, "can't evaluate field Missing")
+
+	client.config = &Config{Templates: map[string]string{"bad": "{{.PVCName"}}
+	assert.ErrorContains(t, client.config.compile(), "templates:")
+}
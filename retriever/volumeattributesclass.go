@@ -0,0 +1,189 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetPVCVolumeAttributesClassRequest defines API request type
+type GetPVCVolumeAttributesClassRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+// VolumeAttributesClassMetadata holds the metadata of a
+// VolumeAttributesClass
+type VolumeAttributesClassMetadata struct {
+	Name       string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DriverName string            `protobuf:"bytes,2,opt,name=driver_name,proto3" json:"driver_name,omitempty"`
+	Labels     map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Parameters map[string]string `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// volumeAttributesClassVersions lists the versions of the
+// VolumeAttributesClass API in order of preference. It is GA in
+// Kubernetes 1.34 and beta from 1.31.
+var volumeAttributesClassVersions = []string{"v1", "v1beta1"}
+
+// GetPVCVolumeAttributesClassResponse defines API response type.
+// CurrentClass and TargetClass are nil when the PVC has no such class or
+// the class does not exist.
+type GetPVCVolumeAttributesClassResponse struct {
+	CurrentClassName string `protobuf:"bytes,1,opt,name=current_class_name,proto3" json:"current_class_name,omitempty"`
+	TargetClassName  string `protobuf:"bytes,2,opt,name=target_class_name,proto3" json:"target_class_name,omitempty"`
+	// ModifyVolumeStatus is Pending, InProgress or Infeasible while a
+	// modification is outstanding and empty otherwise.
+	ModifyVolumeStatus string                         `protobuf:"bytes,3,opt,name=modify_volume_status,proto3" json:"modify_volume_status,omitempty"`
+	CurrentClass       *VolumeAttributesClassMetadata `protobuf:"bytes,4,opt,name=current_class,proto3" json:"current_class,omitempty"`
+	TargetClass        *VolumeAttributesClassMetadata `protobuf:"bytes,5,opt,name=target_class,proto3" json:"target_class,omitempty"`
+}
+
+// GetPVCVolumeAttributesClass gets the VolumeAttributesClass a PVC is
+// currently using and the one it is being modified to. The PVC is read
+// from the API server, not the informer cache, as its status changes
+// while a modification is in progress. If the PVC names a class and no
+// version of the VolumeAttributesClass API is served, an Unimplemented
+// error is returned.
+func (s *MetadataRetrieverClientType) GetPVCVolumeAttributesClass(
+	ctx context.Context,
+	req *GetPVCVolumeAttributesClassRequest) (
+	*GetPVCVolumeAttributesClassResponse, error,
+) {
+	log.Infof("Get VolumeAttributesClass of PVC %s in namespace %s", req.Name, req.NameSpace)
+	if req.Name == "" {
+		return nil, errors.New("PVC Name cannot be empty")
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	pvc, err := clientset.CoreV1().PersistentVolumeClaims(req.NameSpace).Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Error("Error retrieving PVC info: ", err)
+		return nil, err
+	}
+	if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+		log.Error("Error checking PVC access: ", err)
+		return nil, err
+	}
+
+	resp := &GetPVCVolumeAttributesClassResponse{}
+	if pvc.Status.CurrentVolumeAttributesClassName != nil {
+		resp.CurrentClassName = *pvc.Status.CurrentVolumeAttributesClassName
+	}
+	if pvc.Spec.VolumeAttributesClassName != nil {
+		resp.TargetClassName = *pvc.Spec.VolumeAttributesClassName
+	}
+	if status := pvc.Status.ModifyVolumeStatus; status != nil {
+		resp.ModifyVolumeStatus = string(status.Status)
+		if status.TargetVolumeAttributesClassName != "" {
+			resp.TargetClassName = status.TargetVolumeAttributesClassName
+		}
+	}
+
+	if resp.CurrentClassName == "" && resp.TargetClassName == "" {
+		return resp, nil
+	}
+	gvr, err := s.volumeAttributesClassGVR()
+	if err != nil {
+		log.Error("Error resolving VolumeAttributesClass API version: ", err)
+		return nil, err
+	}
+	if resp.CurrentClass, err = s.getVolumeAttributesClass(ctx, clientset, gvr.Version, resp.CurrentClassName); err != nil {
+		log.Error("Error retrieving VolumeAttributesClass info: ", err)
+		return nil, err
+	}
+	if resp.TargetClass, err = s.getVolumeAttributesClass(ctx, clientset, gvr.Version, resp.TargetClassName); err != nil {
+		log.Error("Error retrieving VolumeAttributesClass info: ", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// getVolumeAttributesClass returns the metadata of the named class read
+// through the given API version, or nil if name is empty or the class
+// does not exist.
+func (s *MetadataRetrieverClientType) getVolumeAttributesClass(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	version, name string,
+) (*VolumeAttributesClassMetadata, error) {
+	if name == "" {
+		return nil, nil
+	}
+	var (
+		meta       metav1.ObjectMeta
+		driverName string
+		parameters map[string]string
+	)
+	switch version {
+	case "v1beta1":
+		vac, err := clientset.StorageV1beta1().VolumeAttributesClasses().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		meta, driverName, parameters = vac.ObjectMeta, vac.DriverName, vac.Parameters
+	default:
+		vac, err := clientset.StorageV1().VolumeAttributesClasses().Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		meta, driverName, parameters = vac.ObjectMeta, vac.DriverName, vac.Parameters
+	}
+	return &VolumeAttributesClassMetadata{
+		Name:       meta.Name,
+		DriverName: driverName,
+		Labels:     s.redactMap(meta.Labels),
+		Parameters: copyMap(parameters),
+	}, nil
+}
+
+// volumeAttributesClassGVR returns the most preferred served version of
+// the VolumeAttributesClass API.
+func (s *MetadataRetrieverClientType) volumeAttributesClassGVR() (schema.GroupVersionResource, error) {
+	gvr := schema.GroupVersionResource{Group: "storage.k8s.io", Resource: "volumeattributesclasses"}
+	for _, version := range volumeAttributesClassVersions {
+		gvr.Version = version
+		served, err := s.resourceServed(gvr)
+		if err != nil {
+			return gvr, err
+		}
+		if served {
+			return gvr, nil
+		}
+	}
+	gvr.Version = volumeAttributesClassVersions[0]
+	return gvr, errResourceNotServed(gvr)
+}
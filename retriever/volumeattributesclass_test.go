@@ -0,0 +1,175 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func stringPtr(s string) *string { return &s }
+
+func volumeAttributesClassAPIResources(version string) *metav1.APIResourceList {
+	return &metav1.APIResourceList{
+		GroupVersion: "storage.k8s.io/" + version,
+		APIResources: []metav1.APIResource{{Name: "volumeattributesclasses", Kind: "VolumeAttributesClass"}},
+	}
+}
+
+func newTestVolumeAttributesClass(name string, params map[string]string) *storagev1.VolumeAttributesClass {
+	return &storagev1.VolumeAttributesClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"tier": name}},
+		DriverName: "csi.example.com",
+		Parameters: params,
+	}
+}
+
+func TestGetPVCVolumeAttributesClass(t *testing.T) {
+	modifying := newTestPVC("modifying", "default", nil)
+	modifying.Spec.VolumeAttributesClassName = stringPtr("gold")
+	modifying.Status.CurrentVolumeAttributesClassName = stringPtr("silver")
+	modifying.Status.ModifyVolumeStatus = &v1.ModifyVolumeStatus{
+		TargetVolumeAttributesClassName: "gold",
+		Status:                          v1.PersistentVolumeClaimModifyVolumeInProgress,
+	}
+	settled := newTestPVC("settled", "default", nil)
+	settled.Spec.VolumeAttributesClassName = stringPtr("silver")
+	settled.Status.CurrentVolumeAttributesClassName = stringPtr("silver")
+	infeasible := newTestPVC("infeasible", "default", nil)
+	infeasible.Spec.VolumeAttributesClassName = stringPtr("missing")
+	infeasible.Status.ModifyVolumeStatus = &v1.ModifyVolumeStatus{
+		TargetVolumeAttributesClassName: "missing",
+		Status:                          v1.PersistentVolumeClaimModifyVolumeInfeasible,
+	}
+
+	fakeClientset := fake.NewSimpleClientset(
+		modifying, settled, infeasible, newTestPVC("plain", "default", nil),
+		newTestVolumeAttributesClass("silver", map[string]string{"iops": "1000"}),
+		newTestVolumeAttributesClass("gold", map[string]string{"iops": "5000"}),
+	)
+	fakeClientset.Resources = []*metav1.APIResourceList{volumeAttributesClassAPIResources("v1")}
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	silver := &VolumeAttributesClassMetadata{
+		Name:       "silver",
+		DriverName: "csi.example.com",
+		Labels:     map[string]string{"tier": "silver"},
+		Parameters: map[string]string{"iops": "1000"},
+	}
+	gold := &VolumeAttributesClassMetadata{
+		Name:       "gold",
+		DriverName: "csi.example.com",
+		Labels:     map[string]string{"tier": "gold"},
+		Parameters: map[string]string{"iops": "5000"},
+	}
+
+	tests := []struct {
+		name string
+		want *GetPVCVolumeAttributesClassResponse
+	}{
+		{"modifying", &GetPVCVolumeAttributesClassResponse{
+			CurrentClassName:   "silver",
+			TargetClassName:    "gold",
+			ModifyVolumeStatus: "InProgress",
+			CurrentClass:       silver,
+			TargetClass:        gold,
+		}},
+		{"settled", &GetPVCVolumeAttributesClassResponse{
+			CurrentClassName: "silver",
+			TargetClassName:  "silver",
+			CurrentClass:     silver,
+			TargetClass:      silver,
+		}},
+		{"infeasible", &GetPVCVolumeAttributesClassResponse{
+			TargetClassName:    "missing",
+			ModifyVolumeStatus: "Infeasible",
+		}},
+		{"plain", &GetPVCVolumeAttributesClassResponse{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.GetPVCVolumeAttributesClass(context.Background(),
+				&GetPVCVolumeAttributesClassRequest{Name: tt.name, NameSpace: "default"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp)
+		})
+	}
+}
+
+func TestGetPVCVolumeAttributesClass_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.GetPVCVolumeAttributesClass(context.Background(), &GetPVCVolumeAttributesClassRequest{})
+	assert.EqualError(t, err, "PVC Name cannot be empty")
+
+	_, err = client.GetPVCVolumeAttributesClass(context.Background(), &GetPVCVolumeAttributesClassRequest{Name: "missing", NameSpace: "default"})
+	assert.Contains(t, err.Error(), "not found")
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.GetPVCVolumeAttributesClass(context.Background(), &GetPVCVolumeAttributesClassRequest{Name: "pvc", NameSpace: "default"})
+	assert.EqualError(t, err, "simulated clientset creation error")
+
+	pvc := newTestPVC("pvc", "default", nil)
+	pvc.Spec.VolumeAttributesClassName = stringPtr("gold")
+	fakeClientset := fake.NewSimpleClientset(pvc)
+	fakeClientset.Resources = []*metav1.APIResourceList{volumeAttributesClassAPIResources("v1")}
+	fakeClientset.PrependReactor("get", "volumeattributesclasses", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("get failed")
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.GetPVCVolumeAttributesClass(context.Background(), &GetPVCVolumeAttributesClassRequest{Name: "pvc", NameSpace: "default"})
+	assert.EqualError(t, err, "get failed")
+
+	// No version of the API is served.
+	fakeClientset = fake.NewSimpleClientset(pvc)
+	client = createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+	_, err = client.GetPVCVolumeAttributesClass(context.Background(), &GetPVCVolumeAttributesClassRequest{Name: "pvc", NameSpace: "default"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestGetPVCVolumeAttributesClass_V1beta1(t *testing.T) {
+	pvc := newTestPVC("pvc", "default", nil)
+	pvc.Spec.VolumeAttributesClassName = stringPtr("gold")
+	fakeClientset := fake.NewSimpleClientset(pvc, &storagev1beta1.VolumeAttributesClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "gold", Labels: map[string]string{"tier": "gold"}},
+		DriverName: "csi.example.com",
+		Parameters: map[string]string{"iops": "5000"},
+	})
+	fakeClientset.Resources = []*metav1.APIResourceList{volumeAttributesClassAPIResources("v1beta1")}
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	resp, err := client.GetPVCVolumeAttributesClass(context.Background(), &GetPVCVolumeAttributesClassRequest{Name: "pvc", NameSpace: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, &VolumeAttributesClassMetadata{
+		Name:       "gold",
+		DriverName: "csi.example.com",
+		Labels:     map[string]string{"tier": "gold"},
+		Parameters: map[string]string{"iops": "5000"},
+	}, resp.TargetClass)
+}
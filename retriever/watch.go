@@ -0,0 +1,351 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// PVCMetadataEventType is the kind of change reported by WatchPVCMetadata
+type PVCMetadataEventType string
+
+const (
+	// PVCMetadataAdded is reported when a PVC starts matching the watch
+	PVCMetadataAdded PVCMetadataEventType = "ADDED"
+
+	// PVCMetadataModified is reported when the labels or annotations of a PVC change
+	PVCMetadataModified PVCMetadataEventType = "MODIFIED"
+
+	// PVCMetadataDeleted is reported when a PVC is deleted
+	PVCMetadataDeleted PVCMetadataEventType = "DELETED"
+)
+
+// WatchPVCMetadataRequest defines API request type
+type WatchPVCMetadataRequest struct {
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace     string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	LabelSelector string `protobuf:"bytes,3,opt,name=label_selector,proto3" json:"label_selector,omitempty"`
+	// ResourceVersion resumes a previous watch from the resource version of
+	// the last event received. Only changes made after it are reported. If
+	// the API server no longer holds that version, the stream fails with
+	// OutOfRange and the client has to relist and watch without it.
+	ResourceVersion string `protobuf:"bytes,4,opt,name=resource_version,proto3" json:"resource_version,omitempty"`
+}
+
+// WatchPVCMetadataResponse defines API response type
+type WatchPVCMetadataResponse struct {
+	Type            PVCMetadataEventType `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name            string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NameSpace       string               `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	UID             string               `protobuf:"bytes,4,opt,name=uid,proto3" json:"uid,omitempty"`
+	ResourceVersion string               `protobuf:"bytes,5,opt,name=resource_version,proto3" json:"resource_version,omitempty"`
+	OldLabels       map[string]string    `protobuf:"bytes,6,rep,name=old_labels,proto3" json:"old_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NewLabels       map[string]string    `protobuf:"bytes,7,rep,name=new_labels,proto3" json:"new_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	OldAnnotations  map[string]string    `protobuf:"bytes,8,rep,name=old_annotations,proto3" json:"old_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NewAnnotations  map[string]string    `protobuf:"bytes,9,rep,name=new_annotations,proto3" json:"new_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+// WatchPVCMetadataClient is the receiving side of a WatchPVCMetadata stream
+type WatchPVCMetadataClient interface {
+	// Recv blocks until the next event is available or the stream's
+	// context is done, in which case the context error is returned.
+	Recv() (*WatchPVCMetadataResponse, error)
+}
+
+type pvcMetadataStream struct {
+	ctx    context.Context
+	events chan *WatchPVCMetadataResponse
+	errs   chan error
+}
+
+func (st *pvcMetadataStream) Recv() (*WatchPVCMetadataResponse, error) {
+	select {
+	case ev := <-st.events:
+		return ev, nil
+	case err := <-st.errs:
+		return nil, err
+	case <-st.ctx.Done():
+		return nil, st.ctx.Err()
+	}
+}
+
+func (st *pvcMetadataStream) send(ev *WatchPVCMetadataResponse) {
+	select {
+	case st.events <- ev:
+	case <-st.ctx.Done():
+	}
+}
+
+// WatchPVCMetadata streams label and annotation changes of the PVCs
+// selected by name, namespace and/or label selector, shaped like the
+// metadata returned by GetPVCLabels. A new watch is backed
+// by an informer, a resumed one by a watch that starts at
+// req.ResourceVersion. Either runs until ctx is cancelled.
+func (s *MetadataRetrieverClientType) WatchPVCMetadata(
+	ctx context.Context,
+	req *WatchPVCMetadataRequest) (
+	WatchPVCMetadataClient, error,
+) {
+	log.Infof("Watch PVC metadata for name %q in namespace %q with selector %q",
+		req.Name, req.NameSpace, req.LabelSelector)
+
+	if _, err := labels.Parse(req.LabelSelector); err != nil {
+		log.Error("Invalid label selector: ", err)
+		return nil, err
+	}
+
+	output, err := s.outputFor(nil, "")
+	if err != nil {
+		log.Error("Error reading output options: ", err)
+		return nil, err
+	}
+
+	clientset, err := s.getClientset()
+	if err != nil {
+		log.Error("Error creating clientset: ", err)
+		return nil, err
+	}
+
+	tweakListOptions := func(options *metav1.ListOptions) {
+		options.LabelSelector = req.LabelSelector
+		if req.Name != "" {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", req.Name).String()
+		}
+	}
+
+	stream := &pvcMetadataStream{
+		ctx:    ctx,
+		events: make(chan *WatchPVCMetadataResponse),
+		errs:   make(chan error, 1),
+	}
+
+	matches := func(pvc *v1.PersistentVolumeClaim) bool {
+		if req.Name != "" && pvc.Name != req.Name {
+			return false
+		}
+		if err := s.checkPVCAccess(ctx, clientset, pvc); err != nil {
+			log.Debugf("Skipping PVC %s/%s: %v", pvc.Namespace, pvc.Name, err)
+			return false
+		}
+		return true
+	}
+
+	// emit sends an event with the metadata shaped like GetPVCLabels does.
+	// Modifications that do not change the shaped metadata are dropped.
+	emit := func(eventType PVCMetadataEventType, oldPVC, newPVC *v1.PersistentVolumeClaim) {
+		ev, err := s.newPVCMetadataEvent(ctx, clientset, output, eventType, oldPVC, newPVC)
+		if err != nil {
+			log.Error("Error matching MetadataPolicy: ", err)
+			return
+		}
+		if eventType == PVCMetadataModified && oldPVC != nil &&
+			maps.Equal(ev.OldLabels, ev.NewLabels) && maps.Equal(ev.OldAnnotations, ev.NewAnnotations) {
+			return
+		}
+		stream.send(ev)
+	}
+
+	if req.ResourceVersion != "" {
+		lw := &cache.ListWatch{
+			WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+				tweakListOptions(&options)
+				return clientset.CoreV1().PersistentVolumeClaims(req.NameSpace).Watch(ctx, options)
+			},
+		}
+		watcher, err := watchtools.NewRetryWatcherWithContext(ctx, req.ResourceVersion, lw)
+		if err != nil {
+			log.Error("Error resuming PVC metadata watch: ", err)
+			return nil, status.Errorf(codes.InvalidArgument, "cannot resume watch: %v", err)
+		}
+		go resumePVCMetadataWatch(ctx, watcher, stream, matches, emit)
+		return stream, nil
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(req.NameSpace),
+		informers.WithTweakListOptions(tweakListOptions))
+	informer := factory.Core().V1().PersistentVolumeClaims().Informer()
+	watchErrorHandler, listErrs := watchErrors("PVC metadata watch error: ")
+	if err := informer.SetWatchErrorHandler(watchErrorHandler); err != nil {
+		return nil, err
+	}
+
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pvc, ok := obj.(*v1.PersistentVolumeClaim)
+			if !ok || !matches(pvc) {
+				return
+			}
+			emit(PVCMetadataAdded, nil, pvc)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPVC, ok := oldObj.(*v1.PersistentVolumeClaim)
+			if !ok {
+				return
+			}
+			newPVC, ok := newObj.(*v1.PersistentVolumeClaim)
+			if !ok || !matches(newPVC) {
+				return
+			}
+			emit(PVCMetadataModified, oldPVC, newPVC)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			pvc, ok := obj.(*v1.PersistentVolumeClaim)
+			if !ok || !matches(pvc) {
+				return
+			}
+			emit(PVCMetadataDeleted, pvc, nil)
+		},
+	})
+	if err != nil {
+		log.Error("Error registering PVC event handler: ", err)
+		return nil, err
+	}
+
+	informerCtx, stop := context.WithCancel(ctx)
+	factory.Start(informerCtx.Done())
+	go func() {
+		defer stop()
+		<-informerCtx.Done()
+		factory.Shutdown()
+	}()
+
+	synced, err := waitForSync(ctx, informer.HasSynced, listErrs)
+	if err != nil {
+		stop()
+		log.Error("Error listing PVCs: ", err)
+		return nil, err
+	}
+	if !synced {
+		stop()
+		return nil, errors.New("PVC informer failed to sync")
+	}
+
+	return stream, nil
+}
+
+// newPVCMetadataEvent returns an event with the old and new metadata
+// shaped by output and the MetadataPolicy rule each PVC matches.
+func (s *MetadataRetrieverClientType) newPVCMetadataEvent(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	output *outputOptions,
+	eventType PVCMetadataEventType,
+	oldPVC, newPVC *v1.PersistentVolumeClaim,
+) (*WatchPVCMetadataResponse, error) {
+	ev := &WatchPVCMetadataResponse{Type: eventType}
+	current := newPVC
+	if current == nil {
+		current = oldPVC
+	}
+	ev.Name = current.Name
+	ev.NameSpace = current.Namespace
+	ev.UID = string(current.UID)
+	ev.ResourceVersion = current.ResourceVersion
+	var err error
+	if oldPVC != nil {
+		if ev.OldLabels, ev.OldAnnotations, err = s.pvcMetadata(ctx, clientset, nil, output, oldPVC); err != nil {
+			return nil, err
+		}
+	}
+	if newPVC != nil {
+		if ev.NewLabels, ev.NewAnnotations, err = s.pvcMetadata(ctx, clientset, nil, output, newPVC); err != nil {
+			return nil, err
+		}
+	}
+	return ev, nil
+}
+
+// resumePVCMetadataWatch forwards the events of a watch resumed from a
+// client's resource version to stream. The PVCs as they were at that
+// version are unknown, so the first MODIFIED event of a PVC carries no old
+// labels or annotations. A 410 Gone from the API server ends the stream
+// with OutOfRange so that the client relists.
+func resumePVCMetadataWatch(
+	ctx context.Context,
+	watcher *watchtools.RetryWatcher,
+	stream *pvcMetadataStream,
+	matches func(*v1.PersistentVolumeClaim) bool,
+	emit func(PVCMetadataEventType, *v1.PersistentVolumeClaim, *v1.PersistentVolumeClaim),
+) {
+	defer watcher.Stop()
+
+	seen := map[types.UID]*v1.PersistentVolumeClaim{}
+	for {
+		var event watch.Event
+		var ok bool
+		select {
+		case event, ok = <-watcher.ResultChan():
+		case <-ctx.Done():
+			return
+		}
+		if !ok {
+			if ctx.Err() == nil {
+				stream.errs <- status.Error(codes.Unavailable, "PVC metadata watch closed")
+			}
+			return
+		}
+
+		if event.Type == watch.Error {
+			err := apierrors.FromObject(event.Object)
+			log.Warn("PVC metadata watch error: ", err)
+			if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+				err = status.Errorf(codes.OutOfRange,
+					"resource version is too old, relist and watch again: %v", err)
+			}
+			stream.errs <- err
+			return
+		}
+
+		pvc, ok := event.Object.(*v1.PersistentVolumeClaim)
+		if !ok || !matches(pvc) {
+			continue
+		}
+		switch event.Type {
+		case watch.Added:
+			seen[pvc.UID] = pvc
+			emit(PVCMetadataAdded, nil, pvc)
+		case watch.Modified:
+			old := seen[pvc.UID]
+			seen[pvc.UID] = pvc
+			emit(PVCMetadataModified, old, pvc)
+		case watch.Deleted:
+			delete(seen, pvc.UID)
+			emit(PVCMetadataDeleted, pvc, nil)
+		}
+	}
+}
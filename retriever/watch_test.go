@@ -0,0 +1,218 @@
+/*
+ *
+ * Copyright © 2026 Dell Inc. or its subsidiaries. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package retriever
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newTestPVC(name, namespace string, lbls map[string]string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       types.UID("uid-" + name),
+			Labels:    lbls,
+		},
+	}
+}
+
+func recvWithTimeout(t *testing.T, stream WatchPVCMetadataClient) *WatchPVCMetadataResponse {
+	t.Helper()
+	type result struct {
+		ev  *WatchPVCMetadataResponse
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		ev, err := stream.Recv()
+		ch <- result{ev, err}
+	}()
+	select {
+	case r := <-ch:
+		require.NoError(t, r.err)
+		return r.ev
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for PVC metadata event")
+	}
+	return nil
+}
+
+func TestWatchPVCMetadata_Events(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(newTestPVC("mypvc", "default", map[string]string{"team": "a"}))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchPVCMetadata(ctx, &WatchPVCMetadataRequest{NameSpace: "default"})
+	require.NoError(t, err)
+
+	ev := recvWithTimeout(t, stream)
+	assert.Equal(t, PVCMetadataAdded, ev.Type)
+	assert.Equal(t, "mypvc", ev.Name)
+	assert.Equal(t, "uid-mypvc", ev.UID)
+	assert.Nil(t, ev.OldLabels)
+	assert.Equal(t, map[string]string{"team": "a"}, ev.NewLabels)
+
+	pvcs := fakeClientset.CoreV1().PersistentVolumeClaims("default")
+	_, err = pvcs.Update(ctx, newTestPVC("mypvc", "default", map[string]string{"team": "b"}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	ev = recvWithTimeout(t, stream)
+	assert.Equal(t, PVCMetadataModified, ev.Type)
+	assert.Equal(t, map[string]string{"team": "a"}, ev.OldLabels)
+	assert.Equal(t, map[string]string{"team": "b"}, ev.NewLabels)
+
+	require.NoError(t, pvcs.Delete(ctx, "mypvc", metav1.DeleteOptions{}))
+
+	ev = recvWithTimeout(t, stream)
+	assert.Equal(t, PVCMetadataDeleted, ev.Type)
+	assert.Equal(t, map[string]string{"team": "b"}, ev.OldLabels)
+	assert.Nil(t, ev.NewLabels)
+
+	cancel()
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWatchPVCMetadata_NameAndResume(t *testing.T) {
+	seen := newTestPVC("seen", "default", map[string]string{"a": "1"})
+	seen.ResourceVersion = "5"
+	fakeClientset := fake.NewSimpleClientset(seen, newTestPVC("other", "default", nil))
+	watchStarted := make(chan metav1.ListOptions, 1)
+	fakeClientset.PrependWatchReactor("persistentvolumeclaims", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := fakeClientset.Tracker().Watch(action.GetResource(), action.GetNamespace())
+		select {
+		case watchStarted <- action.(k8stesting.WatchActionImpl).ListOptions:
+		default:
+		}
+		return true, w, err
+	})
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchPVCMetadata(ctx, &WatchPVCMetadataRequest{
+		Name:            "seen",
+		NameSpace:       "default",
+		ResourceVersion: "10",
+	})
+	require.NoError(t, err)
+	watchOptions := <-watchStarted
+	assert.Equal(t, "10", watchOptions.ResourceVersion)
+	assert.Equal(t, "metadata.name=seen", watchOptions.FieldSelector)
+
+	// Only changes made after the resource version are delivered; the
+	// PVCs are not listed again and the PVC with a different name is
+	// filtered out.
+	other := newTestPVC("other", "default", map[string]string{"a": "1"})
+	other.ResourceVersion = "11"
+	_, err = fakeClientset.CoreV1().PersistentVolumeClaims("default").Update(ctx, other, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	updated := seen.DeepCopy()
+	updated.Labels = map[string]string{"a": "2"}
+	updated.ResourceVersion = "12"
+	_, err = fakeClientset.CoreV1().PersistentVolumeClaims("default").Update(ctx, updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	ev := recvWithTimeout(t, stream)
+	assert.Equal(t, PVCMetadataModified, ev.Type)
+	assert.Equal(t, "seen", ev.Name)
+	assert.Equal(t, "12", ev.ResourceVersion)
+	assert.Nil(t, ev.OldLabels)
+	assert.Equal(t, map[string]string{"a": "2"}, ev.NewLabels)
+
+	// A further change carries the labels of the previous event.
+	updated = updated.DeepCopy()
+	updated.Labels = map[string]string{"a": "3"}
+	updated.ResourceVersion = "13"
+	_, err = fakeClientset.CoreV1().PersistentVolumeClaims("default").Update(ctx, updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	ev = recvWithTimeout(t, stream)
+	assert.Equal(t, map[string]string{"a": "2"}, ev.OldLabels)
+	assert.Equal(t, map[string]string{"a": "3"}, ev.NewLabels)
+}
+
+func TestWatchPVCMetadata_ResumeExpired(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset()
+	fakeWatcher := watch.NewFake()
+	fakeClientset.PrependWatchReactor("persistentvolumeclaims", k8stesting.DefaultWatchReactor(fakeWatcher, nil))
+	client := createTestClient(func() (kubernetes.Interface, error) { return fakeClientset, nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.WatchPVCMetadata(ctx, &WatchPVCMetadataRequest{ResourceVersion: "10"})
+	require.NoError(t, err)
+
+	expired := apierrors.NewResourceExpired("too old resource version: 10 (20)").ErrStatus
+	fakeWatcher.Error(&expired)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+
+	_, err = client.WatchPVCMetadata(ctx, &WatchPVCMetadataRequest{ResourceVersion: "0"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatchPVCMetadata_Errors(t *testing.T) {
+	client := createTestClient(FakeGetClientset)
+	_, err := client.WatchPVCMetadata(context.Background(), &WatchPVCMetadataRequest{LabelSelector: "a in (b"})
+	assert.Error(t, err)
+
+	client = createTestClient(FakeGetClientsetError)
+	_, err = client.WatchPVCMetadata(context.Background(), &WatchPVCMetadataRequest{})
+	assert.EqualError(t, err, "simulated clientset creation error")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client = createTestClient(FakeGetClientset)
+	_, err = client.WatchPVCMetadata(ctx, &WatchPVCMetadataRequest{})
+	assert.EqualError(t, err, "PVC informer failed to sync")
+
+	// A failing LIST is returned instead of blocking until ctx is done.
+	forbidden := fake.NewSimpleClientset()
+	forbidden.PrependReactor("list", "persistentvolumeclaims", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(v1.Resource("persistentvolumeclaims"), "", errors.New("denied"))
+	})
+	client = createTestClient(func() (kubernetes.Interface, error) { return forbidden, nil })
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = client.WatchPVCMetadata(ctx, &WatchPVCMetadataRequest{NameSpace: "ns1"})
+	assert.True(t, apierrors.IsForbidden(err), "%v", err)
+	assert.NoError(t, ctx.Err())
+}
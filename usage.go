@@ -129,5 +129,64 @@ GLOBAL OPTIONS
     X_CSI_SPEC_DISABLE_LEN_CHECK
         A flag that disables validation of CSI message field lengths.
 
+    X_CSI_RETRIEVER_DRIVER_NAME
+        The CSI driver name used when a request does not specify one.
+
+    X_CSI_RETRIEVER_CLUSTER_NAME
+        The cluster name reported by GetClusterInfo.
+
+    X_CSI_RETRIEVER_OBJECT_ALLOWLIST
+        The comma-separated group/version/resource entries that
+        GetObjectMetadata may read, ex.
+
+            v1/secrets,argoproj.io/v1alpha1/applications
+
+        Invalid entries are ignored. If no value is specified then
+        GetObjectMetadata reads no objects.
+
+    X_CSI_RETRIEVER_CONFIG_FILE
+        The path of the YAML configuration file. If the file cannot be
+        loaded then the error is logged and the defaults are used.
+
+    X_CSI_RETRIEVER_RESTRICT_TO_DRIVER
+        A flag that restricts the PVC requests to the PVCs provisioned
+        by X_CSI_RETRIEVER_DRIVER_NAME. Requests for other PVCs fail with
+        a gRPC error code of "PermissionDenied."
+
+        Please note that every PVC request is denied if this flag is set
+        and X_CSI_RETRIEVER_DRIVER_NAME is not.
+
+    X_CSI_RETRIEVER_ANNOTATION_PREFIX
+        The reserved prefix of the annotations AnnotateVolume may set,
+        ex. array.dell.com/. A trailing slash is added if it is missing.
+
+        If no value is specified then AnnotateVolume fails with a gRPC
+        error code of "FailedPrecondition."
+
+    X_CSI_RETRIEVER_EMIT_EVENTS
+        A flag that enables posting Kubernetes Events to PVCs on metadata
+        retrieval outcomes.
+
+    X_CSI_RETRIEVER_RBAC_CHECK
+        The mode of the RBAC self-check run at startup. Valid values
+        include:
+           * warn - log the missing permissions and start anyway
+           * fail - refuse to start when a permission is missing
+           * off  - skip the check
+
+        The default value is warn.
+
+    X_CSI_RETRIEVER_CACHE_DIR
+        The directory of the persistent metadata cache, which serves the
+        last known metadata while the API server is unreachable.
+
+        If no value is specified then the cache is disabled.
+
+    X_CSI_RETRIEVER_CACHE_MAX_STALENESS
+        How old the metadata served from the persistent cache may be, as
+        a duration such as 30m.
+
+        The default value is 1h.
+
 The flags -?,-h,-help may be used to print this screen.
 `